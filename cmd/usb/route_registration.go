@@ -67,6 +67,9 @@ func (usb *UsbApp) StartRouteRegistration(config *config.Config, log lager.Logge
 		logger.Fatal("greet-failed", err)
 	}
 
+	usb.routesLock.Lock()
+	defer usb.routesLock.Unlock()
+
 	for port, host := range routesToRegister {
 		logger.Info("start-register", lager.Data{"port": port, "host": host})
 		err = client.Register(port, host)
@@ -74,4 +77,31 @@ func (usb *UsbApp) StartRouteRegistration(config *config.Config, log lager.Logge
 			logger.Fatal("register-failed", err)
 		}
 	}
+
+	usb.routerClient = client
+	usb.routes = routesToRegister
+}
+
+//StopRouteRegistration deregisters the routes registered by StartRouteRegistration so that the gorouter stops
+//sending requests to this instance
+func (usb *UsbApp) StopRouteRegistration(log lager.Logger) {
+	logger := log.Session("cf-router-registrar")
+
+	usb.routesLock.Lock()
+	defer usb.routesLock.Unlock()
+
+	if usb.routerClient == nil {
+		return
+	}
+
+	for port, host := range usb.routes {
+		logger.Info("start-unregister", lager.Data{"port": port, "host": host})
+		err := usb.routerClient.Unregister(port, host)
+		if err != nil {
+			logger.Error("unregister-failed", err, lager.Data{"port": port, "host": host})
+		}
+	}
+
+	usb.routerClient = nil
+	usb.routes = nil
 }
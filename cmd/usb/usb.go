@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	loads "github.com/go-openapi/loads"
@@ -12,12 +16,15 @@ import (
 	brokerOps "github.com/SUSE/cf-usb/lib/broker/operations"
 	"github.com/SUSE/cf-usb/lib/config"
 	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/health"
+	"github.com/SUSE/cf-usb/lib/metrics"
 	"github.com/SUSE/cf-usb/lib/mgmt"
 	"github.com/SUSE/cf-usb/lib/mgmt/authentication/uaa"
 	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
 	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/httpclient"
 	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/uaaapi"
 	"github.com/SUSE/cf-usb/lib/mgmt/operations"
+	"github.com/cloudfoundry/gibson"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -31,8 +38,15 @@ type Usb interface {
 type UsbApp struct {
 	config *config.Config
 	logger lager.Logger
+
+	routesLock   sync.Mutex
+	routerClient *gibson.CFRouterClient
+	routes       map[int]string
 }
 
+//defaultShutdownTimeout is how long, in seconds, in-flight requests and broker operations are waited for on shutdown
+const defaultShutdownTimeout = 30
+
 //NewUsbApp creates an instance of UsbApp and returns it's pointer address
 func NewUsbApp() Usb {
 	return &UsbApp{config: &config.Config{}}
@@ -51,6 +65,7 @@ func (usb *UsbApp) GetCommands() []CLICommandProvider {
 func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 	var err error
 	usb.logger = logger
+	configProvider = config.NewInstrumentedProvider(configProvider)
 	usb.config, err = configProvider.LoadConfiguration()
 	if err != nil {
 		fmt.Println("Unable to load configuration", err.Error())
@@ -59,7 +74,7 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 
 	usb.logger.Info("initializing-drivers")
 
-	csmClient := csm.NewCSMClient(usb.logger)
+	csmClients := csm.NewClientCache(usb.logger)
 
 	usb.logger.Info("initializing-broker")
 
@@ -69,16 +84,35 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 	}
 
 	brokerAPI := brokerOps.NewBrokerAPI(swaggerSpec)
-	ccServiceBroker := broker.ConfigureAPI(brokerAPI, csmClient, configProvider, logger)
+	ccServiceBroker := broker.ConfigureAPI(brokerAPI, csmClients, configProvider, logger)
+
+	checker := health.NewChecker()
+	checker.AddCheck("config_provider", configProvider.Ping)
+	checker.SetOptionalChecks(func() map[string]health.Check {
+		return sidecarChecks(configProvider, csmClients)
+	})
+
+	servers := []*http.Server{}
 
 	if usb.config.ManagementAPI != nil {
+		mgmtServer := &http.Server{Addr: usb.config.ManagementAPI.Listen}
+		servers = append(servers, mgmtServer)
+
+		uaaResolved := make(chan struct{})
+		checker.AddCheck("uaa_token_endpoint", func() error {
+			select {
+			case <-uaaResolved:
+				return nil
+			default:
+				return fmt.Errorf("the UAA token endpoint has not been resolved yet")
+			}
+		})
+
 		go func() {
 			logger := usb.logger.Session("management-api")
 
 			logger.Info("starting")
 
-			mgmtaddr := usb.config.ManagementAPI.Listen
-
 			swaggerSpec, err := loads.Analyzed(mgmt.SwaggerJSON, "")
 			if err != nil {
 				logger.Fatal("initializing-swagger-failed", err)
@@ -90,6 +124,7 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 			if err != nil {
 				logger.Fatal("retrieving-uaa-endpoint-failed", err)
 			}
+			close(uaaResolved)
 
 			uaaAuthConfig, err := configProvider.GetUaaAuthConfig()
 			if err != nil {
@@ -112,15 +147,25 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 			ccServiceBroker := ccapi.NewServiceBroker(client, tokenGenerator, usb.config.ManagementAPI.CloudController.API, logger)
 
 			mgmtAPI := operations.NewUsbMgmtAPI(swaggerSpec)
-			api := mgmt.ConfigureAPI(mgmtAPI, auth, configProvider, ccServiceBroker, csmClient, logger, version)
+			api := mgmt.ConfigureAPI(mgmtAPI, auth, configProvider, ccServiceBroker, csmClients, logger, version)
 
 			go func() {
-				config, err := configProvider.LoadConfiguration()
+				conf, err := configProvider.LoadConfiguration()
 				if err != nil {
 					logger.Fatal("config-not-loaded", err)
 				}
+				accounts, err := configProvider.GetBrokerAccounts()
+				if err != nil {
+					logger.Fatal("broker-accounts-not-loaded", err)
+				}
+				credentials := conf.BrokerAPI.Credentials
+				if !config.AuthenticateBroker(accounts, credentials, credentials.Username, credentials.Password) {
+					// The password was rotated, the cloud controller already has the one the broker accepts
+					logger.Info("broker-password-rotated")
+					return
+				}
 				for {
-					err = ccServiceBroker.UpdateAll(config.BrokerAPI.ExternalURL, config.BrokerAPI.Credentials.Username, config.BrokerAPI.Credentials.Password)
+					err = ccServiceBroker.UpdateAll(conf.BrokerAPI.ExternalURL, credentials.Username, credentials.Password)
 					if err == nil {
 						break
 					}
@@ -129,9 +174,30 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 				}
 			}()
 
-			logger.Info("start-listening", lager.Data{"address": mgmtaddr})
-			err = http.ListenAndServe(mgmtaddr, api)
-			if err != nil {
+			mgmtServer.Handler = checker.Wrap(api)
+
+			logger.Info("start-listening", lager.Data{"address": mgmtServer.Addr})
+			err = mgmtServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				logger.Fatal("listening-failed", err)
+			}
+		}()
+	}
+
+	if usb.config.MetricsAPI != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Default.Handler())
+		mux.Handle("/healthz", checker.Handler(true))
+		mux.Handle("/readyz", checker.Handler(true))
+		metricsServer := &http.Server{Addr: usb.config.MetricsAPI.Listen, Handler: mux}
+		servers = append(servers, metricsServer)
+
+		go func() {
+			logger := usb.logger.Session("metrics-api")
+
+			logger.Info("start-listening", lager.Data{"address": metricsServer.Addr})
+			err := metricsServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
 				logger.Fatal("listening-failed", err)
 			}
 		}()
@@ -141,19 +207,115 @@ func (usb *UsbApp) Run(configProvider config.Provider, logger lager.Logger) {
 		go usb.StartRouteRegistration(usb.config, usb.logger)
 	}
 
-	addr := usb.config.BrokerAPI.Listen
+	brokerServer := &http.Server{Addr: usb.config.BrokerAPI.Listen, Handler: checker.WrapPublic(ccServiceBroker)}
+	servers = append(servers, brokerServer)
 
-	if usb.config.BrokerAPI.RequireTLS {
-		usb.logger.Info("start-listening-broker-tls", lager.Data{"address": addr})
-		err = http.ListenAndServeTLS(addr, usb.config.BrokerAPI.ServerCertFile, usb.config.BrokerAPI.ServerKeyFile, ccServiceBroker)
-		if err != nil {
-			usb.logger.Fatal("listening-broker-tls-failed", err)
+	go func() {
+		var err error
+		if usb.config.BrokerAPI.RequireTLS {
+			usb.logger.Info("start-listening-broker-tls", lager.Data{"address": brokerServer.Addr})
+			err = brokerServer.ListenAndServeTLS(usb.config.BrokerAPI.ServerCertFile, usb.config.BrokerAPI.ServerKeyFile)
+			if err != nil && err != http.ErrServerClosed {
+				usb.logger.Fatal("listening-broker-tls-failed", err)
+			}
+		} else {
+			usb.logger.Info("start-listening-broker", lager.Data{"address": brokerServer.Addr})
+			err = brokerServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				usb.logger.Fatal("listening-broker-failed", err)
+			}
 		}
-	} else {
-		usb.logger.Info("start-listening-broker", lager.Data{"address": addr})
-		err = http.ListenAndServe(addr, ccServiceBroker)
-		if err != nil {
-			usb.logger.Fatal("listening-broker-failed", err)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	received := <-signals
+	signal.Stop(signals)
+
+	usb.logger.Info("shutting-down", lager.Data{"signal": received.String()})
+	usb.shutdown(servers, brokerAPI.ServerShutdown, broker.PendingOperations, configProvider)
+}
+
+//shutdown deregisters the routes of the USB, stops accepting requests and waits for the in-flight requests and the
+//background broker operations to complete, up to the shutdown timeout, before closing the config provider.
+//Operations still running at the timeout are logged and the config provider is left open for them until the process exits
+func (usb *UsbApp) shutdown(servers []*http.Server, brokerShutdown func(), pendingOperations func() map[string]string, configProvider config.Provider) {
+	timeout := time.Duration(usb.config.ShutdownTimeout) * time.Second
+	if usb.config.ShutdownTimeout <= 0 {
+		timeout = defaultShutdownTimeout * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	usb.StopRouteRegistration(usb.logger)
+
+	var wait sync.WaitGroup
+	for _, server := range servers {
+		wait.Add(1)
+		go func(server *http.Server) {
+			defer wait.Done()
+			err := server.Shutdown(ctx)
+			if err != nil {
+				usb.logger.Error("server-shutdown-failed", err, lager.Data{"address": server.Addr})
+			}
+		}(server)
+	}
+	wait.Wait()
+
+	operationsDone := make(chan struct{})
+	go func() {
+		brokerShutdown()
+		close(operationsDone)
+	}()
+
+	select {
+	case <-operationsDone:
+	case <-ctx.Done():
+		usb.logger.Error("broker-operations-not-completed", ctx.Err(), lager.Data{"pending-operations": pendingOperations()})
+		usb.logger.Info("config-provider-close-skipped")
+		return
+	}
+
+	err := configProvider.Close()
+	if err != nil {
+		usb.logger.Error("config-provider-close-failed", err)
+	}
+
+	usb.logger.Info("shutdown-completed")
+}
+
+//sidecarChecks checks that the CSM of every driver endpoint answers its status requests
+func sidecarChecks(configProvider config.Provider, csmClients csm.ClientCache) map[string]health.Check {
+	checks := make(map[string]health.Check)
+
+	conf, err := configProvider.LoadConfiguration()
+	if err != nil {
+		checks["driver_endpoints"] = func() error {
+			return err
+		}
+		return checks
+	}
+
+	for instanceID, instance := range conf.Instances {
+		if instance.TargetURL == "" {
+			continue
+		}
+		instanceID, instance := instanceID, instance
+		checks["driver_endpoint:"+instance.Name] = func() error {
+			client, err := csmClients.GetClient(instanceID, csm.Endpoint{
+				Name:              instance.Name,
+				TargetURL:         instance.TargetURL,
+				AuthenticationKey: instance.AuthenticationKey,
+				CaCert:            instance.CaCert,
+				SkipSSLValidation: instance.SkipSsl,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = client.GetStatus()
+			return err
 		}
 	}
+
+	return checks
 }
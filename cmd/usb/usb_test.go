@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/config/mocks"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownClosesConfigProvider(t *testing.T) {
+	assert := assert.New(t)
+	logger := lagertest.NewTestLogger("usb-test")
+	usb := &UsbApp{config: &config.Config{ShutdownTimeout: 1}, logger: logger}
+
+	provider := new(mocks.Provider)
+	provider.On("Close").Return(nil)
+
+	usb.shutdown([]*http.Server{{Addr: ":0"}}, func() {}, func() map[string]string { return nil }, provider)
+
+	provider.AssertCalled(t, "Close")
+	assert.Len(logger.Logs(), 1)
+	assert.Equal("usb-test.shutdown-completed", logger.Logs()[0].Message)
+}
+
+func TestShutdownTimeoutLeavesConfigProviderOpen(t *testing.T) {
+	assert := assert.New(t)
+	logger := lagertest.NewTestLogger("usb-test")
+	usb := &UsbApp{config: &config.Config{ShutdownTimeout: 1}, logger: logger}
+
+	provider := new(mocks.Provider)
+	release := make(chan struct{})
+	defer close(release)
+	pending := map[string]string{"instance": "provision"}
+
+	start := time.Now()
+	usb.shutdown(nil, func() { <-release }, func() map[string]string { return pending }, provider)
+
+	assert.True(time.Since(start) >= time.Second)
+	provider.AssertNotCalled(t, "Close")
+
+	logs := logger.Logs()
+	if assert.Len(logs, 2) {
+		assert.Equal("usb-test.broker-operations-not-completed", logs[0].Message)
+		assert.Equal(lager.ERROR, logs[0].LogLevel)
+		assert.Equal(map[string]interface{}{"instance": "provision"}, logs[0].Data["pending-operations"])
+		assert.Equal("usb-test.config-provider-close-skipped", logs[1].Message)
+	}
+}
@@ -68,14 +68,14 @@ func setupEnv() (*operations.BrokerAPI, error) {
 		serviceID = instance.Service.ID
 		break
 	}
-	csmInterface := csm.NewCSMClient(logger)
+	csmClients := csm.NewClientCache(logger)
 	swaggerSpec, err := loads.Analyzed(broker.SwaggerJSON, "")
 	if err != nil {
 		return nil, err
 	}
 	brokerAPI := operations.NewBrokerAPI(swaggerSpec)
 
-	broker.ConfigureAPI(brokerAPI, csmInterface, configProvider, logger)
+	broker.ConfigureAPI(brokerAPI, csmClients, configProvider, logger)
 
 	return brokerAPI, nil
 }
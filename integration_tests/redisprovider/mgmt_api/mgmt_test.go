@@ -16,6 +16,7 @@ import (
 	"github.com/SUSE/cf-usb/lib/mgmt/authentication/uaa"
 	loads "github.com/go-openapi/loads"
 
+	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
 	sbMocks "github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi/mocks"
 
 	"github.com/SUSE/cf-usb/lib/mgmt/operations"
@@ -94,8 +95,8 @@ func initMgmt(provider config.Provider) (*operations.UsbMgmtAPI, error) {
 		return nil, err
 	}
 
-	csmClient := csm.NewCSMClient(logger)
-	mgmt.ConfigureAPI(mgmtAPI, auth, provider, sbMocked, csmClient, logger, "t.t.t")
+	csmClients := csm.NewClientCache(logger)
+	mgmt.ConfigureAPI(mgmtAPI, auth, provider, sbMocked, csmClients, logger, "t.t.t")
 
 	return mgmtAPI, nil
 }
@@ -131,9 +132,10 @@ func Test_RegisterDriverEndpoint(t *testing.T) {
 	}
 
 	sbMocked.Mock.On("CheckServiceNameExists", mock.Anything).Return(false)
-	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return("aguid", nil)
+	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
 	sbMocked.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	sbMocked.Mock.On("Update", "aguid", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
 	sbMocked.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
 
 	params := &operations.RegisterDriverEndpointParams{}
@@ -231,9 +233,10 @@ func Test_GetDriverEndpoint(t *testing.T) {
 		t.Error(err)
 	}
 	sbMocked.Mock.On("CheckServiceNameExists", mock.Anything).Return(false)
-	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return("aguid", nil)
+	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
 	sbMocked.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	sbMocked.Mock.On("Update", "aguid", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
 	sbMocked.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
 
 	instanceID := uuid.NewV4().String()
@@ -301,11 +304,12 @@ func Test_UnregisterDriverEndpoint(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return("aguid", nil)
+	sbMocked.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
 	sbMocked.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	sbMocked.Mock.On("Update", "aguid", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sbMocked.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
 	sbMocked.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
-	sbMocked.Mock.On("Delete", "usb").Return(nil)
+	sbMocked.Mock.On("Delete", ccapi.BrokerName("usb")).Return(nil)
 	sbMocked.Mock.On("CheckServiceInstancesExist", mock.Anything).Return(false)
 
 	instanceID := uuid.NewV4().String()
@@ -0,0 +1,235 @@
+package broker
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//Operation tokens returned to the Cloud Controller with 202 responses
+const (
+	provisionOperation   string = "provision"
+	deprovisionOperation string = "deprovision"
+	updateOperation      string = "update"
+	bindOperation        string = "bind"
+	unbindOperation      string = "unbind"
+)
+
+//background tracks the work the broker carries on with after answering a request, so that shutdown can wait for it
+var background sync.WaitGroup
+
+//asyncOperationTTL is how long the outcome of a finished operation is kept for the platform to poll it
+var asyncOperationTTL = time.Hour
+
+//asyncOperation holds the state of a CSM call running in the background
+type asyncOperation struct {
+	kind       string
+	serviceID  string
+	done       bool
+	err        error
+	finishedAt time.Time
+}
+
+//asyncOperations tracks the background operations started by the broker and the removals of the resources failed
+//operations left behind, keyed by resource id
+type asyncOperations struct {
+	sync.Mutex
+	logger     lager.Logger
+	operations map[string]*asyncOperation
+	cleanups   map[string]int
+}
+
+func newAsyncOperations(logger lager.Logger) *asyncOperations {
+	return &asyncOperations{
+		logger:     logger.Session("async-operations"),
+		operations: make(map[string]*asyncOperation),
+		cleanups:   make(map[string]int),
+	}
+}
+
+//startIfIdle runs work in the background and records its outcome under id, unless an operation or a cleanup is still
+//running for id. The check and the record are made under the same lock, so concurrent retries of a request start it
+//only once
+func (a *asyncOperations) startIfIdle(id, kind, serviceID string, work func() error) bool {
+	operation := &asyncOperation{kind: kind, serviceID: serviceID}
+
+	a.Lock()
+	a.expire(time.Now())
+	if running, ok := a.operations[id]; (ok && !running.done) || a.cleanups[id] > 0 {
+		a.Unlock()
+		return false
+	}
+	a.operations[id] = operation
+	a.Unlock()
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		err := a.recovered(id, work)
+
+		a.Lock()
+		operation.done = true
+		operation.err = err
+		operation.finishedAt = time.Now()
+		a.Unlock()
+	}()
+
+	return true
+}
+
+//recovered runs the background work recorded under id and turns a panic into its error, a malformed answer of a
+//sidecar fails the operation instead of the whole process
+func (a *asyncOperations) recovered(id string, work func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Unexpected failure: %v", r)
+			a.logger.Error("background-work-panicked", err, lager.Data{"id": id, "stack": string(debug.Stack())})
+		}
+	}()
+
+	return work()
+}
+
+//startCleanup runs cleanup in the background and records under id that it is running, so that no operation is
+//started for id before the resources a failed operation left behind are removed
+func (a *asyncOperations) startCleanup(id string, cleanup func()) {
+	a.Lock()
+	a.cleanups[id]++
+	a.Unlock()
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		defer func() {
+			a.Lock()
+			a.cleanups[id]--
+			if a.cleanups[id] == 0 {
+				delete(a.cleanups, id)
+			}
+			a.Unlock()
+		}()
+
+		a.recovered(id, func() error {
+			cleanup()
+			return nil
+		})
+	}()
+}
+
+//cleaningUp reports whether the resources a failed operation left behind for id are still being removed
+func (a *asyncOperations) cleaningUp(id string) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	return a.cleanups[id] > 0
+}
+
+//running returns the kind of the operations and cleanups still running, keyed by resource id
+func (a *asyncOperations) running() map[string]string {
+	a.Lock()
+	defer a.Unlock()
+
+	running := make(map[string]string)
+	for id, operation := range a.operations {
+		if !operation.done {
+			running[id] = operation.kind
+		}
+	}
+	for id := range a.cleanups {
+		running[id] = "cleanup"
+	}
+	return running
+}
+
+//PendingOperations returns the kind of the background operations and cleanups of the broker that have not completed,
+//keyed by the id of their service instance or binding
+func PendingOperations() map[string]string {
+	if brokerOperations == nil {
+		return map[string]string{}
+	}
+	return brokerOperations.running()
+}
+
+//expire stops tracking the operations that finished longer than asyncOperationTTL ago, their outcome was either
+//reported already or the platform stopped polling for it. It must be called with the lock held
+func (a *asyncOperations) expire(now time.Time) {
+	for id, operation := range a.operations {
+		if operation.done && now.Sub(operation.finishedAt) > asyncOperationTTL {
+			delete(a.operations, id)
+		}
+	}
+}
+
+//get returns a copy of the operation tracked under id or nil if there is none
+func (a *asyncOperations) get(id string) *asyncOperation {
+	a.Lock()
+	defer a.Unlock()
+
+	operation, ok := a.operations[id]
+	if !ok {
+		return nil
+	}
+	result := *operation
+	return &result
+}
+
+//pending reports whether an operation of the given kind is still running for id
+func (a *asyncOperations) pending(id, kind string) bool {
+	operation := a.get(id)
+	return operation != nil && operation.kind == kind && !operation.done
+}
+
+//forget stops tracking the operation for id once its final state was reported
+func (a *asyncOperations) forget(id string) {
+	a.Lock()
+	defer a.Unlock()
+
+	if operation, ok := a.operations[id]; ok && operation.done {
+		delete(a.operations, id)
+	}
+}
+
+//concurrencyError is returned when a request arrives while another operation is still running for the resource
+func concurrencyError(description string) *brokermodel.AsyncError {
+	return &brokermodel.AsyncError{
+		Error:       "ConcurrencyError",
+		Description: description,
+	}
+}
+
+//lastOperationState maps the CSM processing type and status to a last_operation state
+func lastOperationState(processingType, status *string) string {
+	if status == nil {
+		return inProgress
+	}
+
+	switch *status {
+	case "successful":
+		return succeeded
+	case "failed":
+		return failed
+	case "none":
+		if processingType != nil && *processingType == "none" {
+			return succeeded
+		}
+	}
+
+	return inProgress
+}
+
+//isNoopWorkspace is true for sidecars that do not keep track of workspaces
+func isNoopWorkspace(workspace *models.ServiceManagerWorkspaceResponse) bool {
+	return workspace.ProcessingType != nil && workspace.Status != nil &&
+		*workspace.ProcessingType == "none" && *workspace.Status == "none"
+}
+
+//isNoopConnection is true for sidecars that do not keep track of connections
+func isNoopConnection(connection *models.ServiceManagerConnectionResponse) bool {
+	return connection.ProcessingType != nil && connection.Status != nil &&
+		*connection.ProcessingType == "none" && *connection.Status == "none"
+}
@@ -0,0 +1,142 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastOperationState(t *testing.T) {
+	assert := assert.New(t)
+
+	state := func(value string) *string {
+		return &value
+	}
+
+	cases := []struct {
+		processingType *string
+		status         *string
+		expected       string
+	}{
+		{nil, nil, inProgress},
+		{state("default"), nil, inProgress},
+		{state("default"), state("successful"), succeeded},
+		{state("default"), state("failed"), failed},
+		{state("default"), state("in progress"), inProgress},
+		{state("default"), state("none"), inProgress},
+		{nil, state("none"), inProgress},
+		{state("none"), state("none"), succeeded},
+		{state("default"), state("unknown"), inProgress},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.expected, lastOperationState(c.processingType, c.status), "processing type %v, status %v", c.processingType, c.status)
+	}
+}
+
+func TestStartIfIdle(t *testing.T) {
+	assert := assert.New(t)
+	operations := newAsyncOperations(lagertest.NewTestLogger("async-test"))
+
+	release := make(chan struct{})
+	assert.True(operations.startIfIdle("instance", provisionOperation, "service", func() error {
+		<-release
+		return nil
+	}))
+	assert.False(operations.startIfIdle("instance", provisionOperation, "service", func() error { return nil }))
+	assert.True(operations.pending("instance", provisionOperation))
+
+	close(release)
+	background.Wait()
+
+	assert.False(operations.pending("instance", provisionOperation))
+	assert.True(operations.startIfIdle("instance", deprovisionOperation, "service", func() error { return nil }))
+	background.Wait()
+}
+
+func TestStartIfIdleRecoversPanics(t *testing.T) {
+	assert := assert.New(t)
+	operations := newAsyncOperations(lagertest.NewTestLogger("async-test"))
+
+	assert.True(operations.startIfIdle("instance", provisionOperation, "service", func() error {
+		var message *string
+		panic(*message)
+	}))
+	background.Wait()
+
+	operation := operations.get("instance")
+	assert.True(operation.done)
+	assert.Contains(operation.err.Error(), "Unexpected failure: ")
+	assert.False(operations.pending("instance", provisionOperation))
+}
+
+func TestRunningOperations(t *testing.T) {
+	assert := assert.New(t)
+	operations := newAsyncOperations(lagertest.NewTestLogger("async-test"))
+
+	release := make(chan struct{})
+	assert.True(operations.startIfIdle("instance", provisionOperation, "service", func() error {
+		<-release
+		return nil
+	}))
+	assert.True(operations.startIfIdle("binding", bindOperation, "service", func() error { return nil }))
+	operations.startCleanup("orphan", func() {
+		<-release
+	})
+	for operations.pending("binding", bindOperation) {
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.Equal(map[string]string{"instance": provisionOperation, "orphan": "cleanup"}, operations.running())
+
+	close(release)
+	background.Wait()
+	assert.Empty(operations.running())
+}
+
+func TestExpireFinishedOperations(t *testing.T) {
+	assert := assert.New(t)
+	operations := newAsyncOperations(lagertest.NewTestLogger("async-test"))
+
+	assert.True(operations.startIfIdle("instance", provisionOperation, "service", func() error { return nil }))
+	background.Wait()
+
+	operations.Lock()
+	operations.expire(time.Now())
+	operations.Unlock()
+	assert.NotNil(operations.get("instance"))
+
+	operations.Lock()
+	operations.expire(time.Now().Add(asyncOperationTTL + time.Minute))
+	operations.Unlock()
+	assert.Nil(operations.get("instance"))
+}
+
+func TestIsNoopConnection(t *testing.T) {
+	assert := assert.New(t)
+
+	state := func(value string) *string {
+		return &value
+	}
+
+	cases := []struct {
+		processingType *string
+		status         *string
+		expected       bool
+	}{
+		{nil, nil, false},
+		{state("none"), nil, false},
+		{nil, state("none"), false},
+		{state("default"), state("none"), false},
+		{state("none"), state("successful"), false},
+		{state("none"), state("none"), true},
+	}
+
+	for _, c := range cases {
+		connection := &models.ServiceManagerConnectionResponse{ProcessingType: c.processingType, Status: c.status}
+		assert.Equal(c.expected, isNoopConnection(connection), "processing type %v, status %v", c.processingType, c.status)
+	}
+}
@@ -0,0 +1,138 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/go-openapi/strfmt"
+)
+
+//Catalog requires flags of the services whose bindings carry more than credentials
+const (
+	requiresRouteForwarding string = "route_forwarding"
+	requiresSyslogDrain     string = "syslog_drain"
+	requiresVolumeMount     string = "volume_mount"
+)
+
+//Connection details the CSM returns for services with requires flags
+const (
+	routeServiceURLDetail string = "route_service_url"
+	syslogDrainURLDetail  string = "syslog_drain_url"
+	volumeMountsDetail    string = "volume_mounts"
+)
+
+//syslogDrainSchemes are the drain URL schemes Cloud Foundry can stream logs to
+var syslogDrainSchemes = []string{"syslog", "syslog-tls", "https"}
+
+func serviceRequires(requires []string, flag string) bool {
+	for _, req := range requires {
+		if req == flag {
+			return true
+		}
+	}
+	return false
+}
+
+//newBindingResponse turns the connection details returned by the CSM into a binding response.
+//Route services only get their route service URL, log drains and volume services get the drain URL or volume mounts
+//taken out of the details and the rest of the details as credentials
+func newBindingResponse(instance *config.Instance, details interface{}) (*brokermodel.BindingResponse, error) {
+	bindingResponse := brokermodel.BindingResponse{}
+	requires := instance.Service.Requires
+
+	if serviceRequires(requires, requiresRouteForwarding) {
+		routeInfo, _ := details.(map[string]interface{})
+		bindingResponse.RouteServiceURL, _ = routeInfo[routeServiceURLDetail].(string)
+		return &bindingResponse, nil
+	}
+
+	if !serviceRequires(requires, requiresSyslogDrain) && !serviceRequires(requires, requiresVolumeMount) {
+		bindingResponse.Credentials = details
+		return &bindingResponse, nil
+	}
+
+	credentials := map[string]interface{}{}
+	if detailsMap, ok := details.(map[string]interface{}); ok {
+		for key, value := range detailsMap {
+			credentials[key] = value
+		}
+	}
+
+	if serviceRequires(requires, requiresSyslogDrain) {
+		drainURL, err := syslogDrainURL(credentials[syslogDrainURLDetail])
+		if err != nil {
+			return nil, err
+		}
+		bindingResponse.SyslogDrainURL = drainURL
+		delete(credentials, syslogDrainURLDetail)
+	}
+
+	if serviceRequires(requires, requiresVolumeMount) {
+		mounts, err := volumeMounts(credentials[volumeMountsDetail])
+		if err != nil {
+			return nil, err
+		}
+		bindingResponse.VolumeMounts = mounts
+		delete(credentials, volumeMountsDetail)
+	}
+
+	if len(credentials) > 0 {
+		bindingResponse.Credentials = credentials
+	}
+
+	return &bindingResponse, nil
+}
+
+func syslogDrainURL(detail interface{}) (string, error) {
+	drain, ok := detail.(string)
+	if !ok || drain == "" {
+		return "", fmt.Errorf("The service manager did not return a %s for a syslog_drain service", syslogDrainURLDetail)
+	}
+
+	drainURL, err := url.Parse(drain)
+	if err != nil || drainURL.Host == "" {
+		return "", fmt.Errorf("The service manager returned an invalid %s %q", syslogDrainURLDetail, drain)
+	}
+
+	for _, scheme := range syslogDrainSchemes {
+		if drainURL.Scheme == scheme {
+			return drain, nil
+		}
+	}
+	return "", fmt.Errorf("The service manager returned a %s with the unsupported scheme %q", syslogDrainURLDetail, drainURL.Scheme)
+}
+
+func volumeMounts(detail interface{}) ([]*brokermodel.VolumeMount, error) {
+	if detail == nil {
+		return nil, fmt.Errorf("The service manager did not return %s for a volume_mount service", volumeMountsDetail)
+	}
+
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := []*brokermodel.VolumeMount{}
+	err = json.Unmarshal(data, &mounts)
+	if err != nil {
+		return nil, fmt.Errorf("The service manager returned invalid %s: %s", volumeMountsDetail, err.Error())
+	}
+	if len(mounts) == 0 {
+		return nil, fmt.Errorf("The service manager returned no %s for a volume_mount service", volumeMountsDetail)
+	}
+
+	for _, mount := range mounts {
+		if mount == nil {
+			return nil, fmt.Errorf("The service manager returned an empty entry in %s", volumeMountsDetail)
+		}
+		err = mount.Validate(strfmt.Default)
+		if err != nil {
+			return nil, fmt.Errorf("The service manager returned invalid %s: %s", volumeMountsDetail, err.Error())
+		}
+	}
+
+	return mounts, nil
+}
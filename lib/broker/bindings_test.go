@@ -0,0 +1,69 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBindingResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	instance := func(requires ...string) *config.Instance {
+		return &config.Instance{Service: brokermodel.CatalogService{Requires: requires}}
+	}
+	mount := map[string]interface{}{
+		"driver":        "nfsv3driver",
+		"container_dir": "/data",
+		"mode":          "rw",
+		"device_type":   "shared",
+		"device":        map[string]interface{}{"volume_id": "volume"},
+	}
+
+	cases := []struct {
+		name         string
+		instance     *config.Instance
+		details      interface{}
+		credentials  interface{}
+		routeService string
+		syslogDrain  string
+		volumeMounts int
+		rejected     bool
+	}{
+		{"credentials", instance(), map[string]interface{}{"user": "u"}, map[string]interface{}{"user": "u"}, "", "", 0, false},
+		{"route service", instance(requiresRouteForwarding),
+			map[string]interface{}{"route_service_url": "https://route.example.com", "user": "u"}, nil, "https://route.example.com", "", 0, false},
+		{"syslog drain", instance(requiresSyslogDrain),
+			map[string]interface{}{"syslog_drain_url": "syslog-tls://logs.example.com:6514"}, nil, "", "syslog-tls://logs.example.com:6514", 0, false},
+		{"syslog drain with credentials", instance(requiresSyslogDrain),
+			map[string]interface{}{"syslog_drain_url": "https://logs.example.com", "token": "t"}, map[string]interface{}{"token": "t"}, "", "https://logs.example.com", 0, false},
+		{"missing syslog drain", instance(requiresSyslogDrain), map[string]interface{}{"token": "t"}, nil, "", "", 0, true},
+		{"syslog drain without host", instance(requiresSyslogDrain), map[string]interface{}{"syslog_drain_url": "syslog://"}, nil, "", "", 0, true},
+		{"syslog drain scheme", instance(requiresSyslogDrain), map[string]interface{}{"syslog_drain_url": "ftp://logs.example.com"}, nil, "", "", 0, true},
+		{"volume mount", instance(requiresVolumeMount),
+			map[string]interface{}{"volume_mounts": []interface{}{mount}}, nil, "", "", 1, false},
+		{"volume mount and syslog drain", instance(requiresVolumeMount, requiresSyslogDrain),
+			map[string]interface{}{"volume_mounts": []interface{}{mount}, "syslog_drain_url": "syslog://logs.example.com"}, nil, "", "syslog://logs.example.com", 1, false},
+		{"missing volume mounts", instance(requiresVolumeMount), map[string]interface{}{}, nil, "", "", 0, true},
+		{"no volume mounts", instance(requiresVolumeMount), map[string]interface{}{"volume_mounts": []interface{}{}}, nil, "", "", 0, true},
+		{"incomplete volume mount", instance(requiresVolumeMount),
+			map[string]interface{}{"volume_mounts": []interface{}{map[string]interface{}{"driver": "nfsv3driver"}}}, nil, "", "", 0, true},
+	}
+
+	for _, c := range cases {
+		response, err := newBindingResponse(c.instance, c.details)
+		if c.rejected {
+			assert.Error(err, c.name)
+			continue
+		}
+		if !assert.NoError(err, c.name) {
+			continue
+		}
+		assert.Equal(c.credentials, response.Credentials, c.name)
+		assert.Equal(c.routeService, response.RouteServiceURL, c.name)
+		assert.Equal(c.syslogDrain, response.SyslogDrainURL, c.name)
+		assert.Len(response.VolumeMounts, c.volumeMounts, c.name)
+	}
+}
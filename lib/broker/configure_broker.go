@@ -14,18 +14,26 @@ import (
 	"github.com/SUSE/cf-usb/lib/brokermodel"
 	"github.com/SUSE/cf-usb/lib/config"
 	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/SUSE/cf-usb/lib/httpmiddleware"
+	"github.com/SUSE/cf-usb/lib/metrics"
 	"github.com/pivotal-golang/lager"
 )
 
 var (
-	brokerCsm            csm.CSM
+	brokerCsmClients     csm.ClientCache
 	brokerConfigProvider config.Provider
 	brokerLogger         lager.Logger
+	brokerOperations     *asyncOperations
+	brokerAuth           *brokerCredentials
+	//brokerMinAPIVersion is the oldest Service Broker API version the deployment is configured to accept
+	brokerMinAPIVersion string
 )
 
 const (
-	failed   string = "failed"
-	succeded string = "succeded"
+	failed     string = "failed"
+	succeeded  string = "succeeded"
+	inProgress string = "in progress"
 )
 
 func getBrokerError(s string) *brokermodel.BrokerError {
@@ -35,37 +43,200 @@ func getBrokerError(s string) *brokermodel.BrokerError {
 }
 
 func idLastOperationHandler(params operations.GetServiceInstancesInstanceIDLastOperationParams, principal interface{}) middleware.Responder {
-	//TODO add async
-	exists, isNoop, err := brokerCsm.WorkspaceExists(params.InstanceID)
+	log := requestLogger(params.HTTPRequest, "last-operation")
 
 	payload := &brokermodel.LastOperation{}
 
+	operation := brokerOperations.get(params.InstanceID)
+
+	kind := provisionOperation
+	serviceID := ""
+	if operation != nil {
+		kind = operation.kind
+		serviceID = operation.serviceID
+	}
+	if params.Operation != nil {
+		kind = *params.Operation
+	}
+	if params.ServiceID != nil {
+		serviceID = *params.ServiceID
+	}
+
+	if operation != nil {
+		if !operation.done {
+			payload.State = inProgress
+			log.Info("last-operation-in-progress", lager.Data{"instance-id": params.InstanceID, "operation": kind})
+			return operations.NewGetServiceInstancesInstanceIDLastOperationOK().WithPayload(payload)
+		}
+
+		if operation.err != nil {
+			brokerOperations.forget(params.InstanceID)
+			payload.State = failed
+			payload.Description = operation.err.Error()
+			log.Info("last-operation-failed", lager.Data{"instance-id": params.InstanceID, "operation": kind, "error": operation.err.Error()})
+			return operations.NewGetServiceInstancesInstanceIDLastOperationOK().WithPayload(payload)
+		}
+	}
+
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, serviceID)
+	client = withRequestContext(params.HTTPRequest, client)
+
+	if err != nil {
+		log.Info("last-operation-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceInstancesInstanceIDLastOperationDefault(401).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if instance == nil {
+		log.Info("last-operation-not-in-catalog", lager.Data{"service-id": serviceID})
+		return operations.NewGetServiceInstancesInstanceIDLastOperationDefault(404).WithPayload(getBrokerError(serviceID + " not found"))
+	}
+
+	workspace, err := client.GetWorkspace(params.InstanceID)
+
 	if err != nil {
-		payload.State = failed
-		payload.Description = err.Error()
-		brokerLogger.Info("last-operation-error", lager.Data{"error": err.Error()})
-		return operations.NewGetServiceInstancesInstanceIDLastOperationOK().WithPayload(payload)
+		log.Info("last-operation-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceInstancesInstanceIDLastOperationDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
-	payload.Description = fmt.Sprintf("resources exists = %t; Operation is noop = %t", exists, isNoop)
-	payload.State = succeded
-	brokerLogger.Info("last-operation-completed", lager.Data{"instance-id": params.InstanceID})
+	if kind == deprovisionOperation {
+		if workspace == nil || isNoopWorkspace(workspace) {
+			brokerOperations.forget(params.InstanceID)
+			log.Info("last-operation-completed", lager.Data{"instance-id": params.InstanceID, "operation": kind})
+			return operations.NewGetServiceInstancesInstanceIDLastOperationGone().WithPayload(map[string]interface{}{})
+		}
+
+		// the workspace is still there, so the deletion has not finished yet
+		payload.State = lastOperationState(workspace.ProcessingType, workspace.Status)
+		if payload.State == succeeded {
+			payload.State = inProgress
+		}
+	} else {
+		if workspace == nil {
+			brokerOperations.forget(params.InstanceID)
+			payload.State = failed
+			payload.Description = fmt.Sprintf("Workspace %s not found", params.InstanceID)
+			log.Info("last-operation-missing", lager.Data{"instance-id": params.InstanceID, "operation": kind})
+			return operations.NewGetServiceInstancesInstanceIDLastOperationOK().WithPayload(payload)
+		}
+
+		payload.State = lastOperationState(workspace.ProcessingType, workspace.Status)
+	}
+
+	if payload.State != inProgress {
+		brokerOperations.forget(params.InstanceID)
+	}
+
+	log.Info("last-operation-completed", lager.Data{"instance-id": params.InstanceID, "operation": kind, "state": payload.State})
 	return operations.NewGetServiceInstancesInstanceIDLastOperationOK().WithPayload(payload)
+}
+
+func bindingLastOperationHandler(params operations.GetServiceBindingLastOperationParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "binding-last-operation")
+
+	payload := &brokermodel.LastOperation{}
+
+	operation := brokerOperations.get(params.BindingID)
+
+	kind := bindOperation
+	serviceID := ""
+	if operation != nil {
+		kind = operation.kind
+		serviceID = operation.serviceID
+	}
+	if params.Operation != nil {
+		kind = *params.Operation
+	}
+	if params.ServiceID != nil {
+		serviceID = *params.ServiceID
+	}
+	data := lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID, "operation": kind}
+
+	if operation != nil {
+		if !operation.done {
+			payload.State = inProgress
+			log.Info("binding-last-operation-in-progress", data)
+			return operations.NewGetServiceBindingLastOperationOK().WithPayload(payload)
+		}
+
+		if operation.err != nil {
+			brokerOperations.forget(params.BindingID)
+			payload.State = failed
+			payload.Description = operation.err.Error()
+			log.Info("binding-last-operation-failed", lager.Data{"binding-id": params.BindingID, "operation": kind, "error": operation.err.Error()})
+			return operations.NewGetServiceBindingLastOperationOK().WithPayload(payload)
+		}
+	}
+
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, serviceID)
+	client = withRequestContext(params.HTTPRequest, client)
+
+	if err != nil {
+		log.Info("binding-last-operation-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceBindingLastOperationDefault(401).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if instance == nil {
+		log.Info("binding-last-operation-not-in-catalog", lager.Data{"service-id": serviceID})
+		return operations.NewGetServiceBindingLastOperationDefault(404).WithPayload(getBrokerError(serviceID + " not found"))
+	}
+
+	connection, err := client.GetConnection(params.InstanceID, params.BindingID)
+
+	if err != nil {
+		log.Info("binding-last-operation-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceBindingLastOperationDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if kind == unbindOperation {
+		if connection == nil || isNoopConnection(connection) {
+			brokerOperations.forget(params.BindingID)
+			log.Info("binding-last-operation-completed", data)
+			return operations.NewGetServiceBindingLastOperationGone().WithPayload(map[string]interface{}{})
+		}
+
+		// the connection is still there, so the deletion has not finished yet
+		payload.State = lastOperationState(connection.ProcessingType, connection.Status)
+		if payload.State == succeeded {
+			payload.State = inProgress
+		}
+	} else {
+		if connection == nil {
+			brokerOperations.forget(params.BindingID)
+			payload.State = failed
+			payload.Description = fmt.Sprintf("Binding %s not found", params.BindingID)
+			log.Info("binding-last-operation-missing", data)
+			return operations.NewGetServiceBindingLastOperationOK().WithPayload(payload)
+		}
+
+		payload.State = lastOperationState(connection.ProcessingType, connection.Status)
+	}
 
+	if payload.State != inProgress {
+		brokerOperations.forget(params.BindingID)
+	}
+
+	log.Info("binding-last-operation-completed", lager.Data{"binding-id": params.BindingID, "operation": kind, "state": payload.State})
+	return operations.NewGetServiceBindingLastOperationOK().WithPayload(payload)
 }
 
-func catalogHandler(principal interface{}) middleware.Responder {
+func catalogHandler(params catalog.CatalogParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "catalog")
+
 	var cat = brokermodel.CatalogServices{}
 
 	conf, err := brokerConfigProvider.LoadConfiguration()
 
 	if err != nil {
-		brokerLogger.Info("catalog-request-error", lager.Data{"error": err.Error()})
+		log.Info("catalog-request-error", lager.Data{"error": err.Error()})
 		return catalog.NewCatalogDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
 	for _, instance := range conf.Instances {
 		catServ := instance.Service
+		if supportsAsyncBindings(params.HTTPRequest) {
+			catServ.InstancesRetrievable = true
+			catServ.BindingsRetrievable = true
+		}
 		for _, dial := range instance.Dials {
 			dialTemp := dial
 			catServ.Plans = append(catServ.Plans, &dialTemp.Plan)
@@ -74,213 +245,618 @@ func catalogHandler(principal interface{}) middleware.Responder {
 
 	}
 
-	brokerLogger.Info("catalog-request-completed", lager.Data{"catalog-services": cat.Services})
+	log.Info("catalog-request-completed", lager.Data{"catalog-services": cat.Services})
 
 	return catalog.NewCatalogOK().WithPayload(&cat)
 
 }
 
 func createServiceInstanceHandler(params operations.CreateServiceInstanceParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "provision")
 
-	servID, err := getServiceAfterLogin(brokerCsm, brokerConfigProvider, params.Service.ServiceID)
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, params.Service.ServiceID)
+	client = withRequestContext(params.HTTPRequest, client)
 
 	if err != nil {
-		brokerLogger.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
+		log.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
 		return operations.NewCreateServiceInstanceDefault(401).WithPayload(getBrokerError(err.Error()))
 	}
 
-	if servID == nil {
-		brokerLogger.Info("provision-instance-request-not-in-catalog", lager.Data{"service-id": params.Service.ServiceID})
+	if instance == nil {
+		log.Info("provision-instance-request-not-in-catalog", lager.Data{"service-id": params.Service.ServiceID})
 		return operations.NewCreateServiceInstanceDefault(404).WithPayload(getBrokerError(params.Service.ServiceID + " not found"))
 	}
 
-	exists, isNoop, err := brokerCsm.WorkspaceExists(params.InstanceID)
+	dial, err := getPlanDial(brokerConfigProvider, params.Service.PlanID, params.Service.ServiceID)
 
 	if err != nil {
-		brokerLogger.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
+		log.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
+		return operations.NewCreateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if dial == nil {
+		log.Info("provision-instance-request-plan-not-found", lager.Data{"plan-id": params.Service.PlanID, "service-id": params.Service.ServiceID})
+		return operations.NewCreateServiceInstanceDefault(400).WithPayload(getBrokerError("Plan " + params.Service.PlanID + " not found for service " + params.Service.ServiceID))
+	}
+
+	violations, err := validateParameters(instanceCreateSchema(dial.Plan), params.Service.Parameters)
+	if err != nil {
+		log.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
+		return operations.NewCreateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+	if violations != "" {
+		log.Info("provision-instance-request-invalid-parameters", lager.Data{"plan-id": params.Service.PlanID, "violations": violations})
+		return operations.NewCreateServiceInstanceDefault(400).WithPayload(getBrokerError(violations))
+	}
+
+	if brokerOperations.pending(params.InstanceID, provisionOperation) {
+		log.Info("provision-instance-request-in-progress", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewCreateServiceInstanceAccepted().WithPayload(&brokermodel.DashboardURL{Operation: provisionOperation})
+	}
+
+	if brokerOperations.cleaningUp(params.InstanceID) {
+		log.Info("provision-instance-request-cleanup-in-progress", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewCreateServiceInstanceConflict()
+	}
+
+	exists, isNoop, err := client.WorkspaceExists(params.InstanceID)
+
+	if err != nil {
+		log.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
 		return operations.NewCreateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
 	if exists && !isNoop {
-		brokerLogger.Info("provision-instance-request-conflict", lager.Data{"instance-id": params.InstanceID, "service-id": params.Service.ServiceID})
+		log.Info("provision-instance-request-conflict", lager.Data{"instance-id": params.InstanceID, "service-id": params.Service.ServiceID})
 		return operations.NewCreateServiceInstanceConflict()
 	}
 
-	err = brokerCsm.CreateWorkspace(params.InstanceID)
+	if params.AcceptsIncomplete != nil && *params.AcceptsIncomplete {
+		instanceID := params.InstanceID
+		details := workspaceDetails(params.Service, dial)
+		data := lager.Data{"instance-id": instanceID, "service-id": params.Service.ServiceID}
+
+		service := params.Service
+
+		started := brokerOperations.startIfIdle(instanceID, provisionOperation, params.Service.ServiceID, func() error {
+			registerServiceInstance(log, instanceID, service, config.StateInProgress)
+
+			_, err := client.CreateWorkspace(instanceID, details)
+			if err != nil {
+				log.Error("async-provision-instance-failed", err, data)
+				setServiceInstanceState(log, instanceID, config.StateFailed)
+				mitigateOrphanWorkspace(log, client, instanceID, err)
+				return err
+			}
+			log.Info("async-provision-instance-completed", data)
+			setServiceInstanceState(log, instanceID, config.StateSucceeded)
+			return nil
+		})
+		if !started {
+			log.Info("provision-instance-request-in-progress", data)
+			return operations.NewCreateServiceInstanceAccepted().WithPayload(&brokermodel.DashboardURL{Operation: provisionOperation})
+		}
+
+		log.Info("provision-instance-request-accepted", data)
+
+		return operations.NewCreateServiceInstanceAccepted().WithPayload(&brokermodel.DashboardURL{Operation: provisionOperation})
+	}
+
+	workspace, err := client.CreateWorkspace(params.InstanceID, workspaceDetails(params.Service, dial))
 
 	if err != nil {
+		log.Info("provision-instance-request-error", lager.Data{"error": err.Error()})
+		mitigateOrphanWorkspace(log, client, params.InstanceID, err)
 		return operations.NewCreateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
-	brokerLogger.Info("provision-instance-request-completed", lager.Data{"instance-id": params.InstanceID, "service-id": params.Service.ServiceID})
+	registerServiceInstance(log, params.InstanceID, params.Service, config.StateSucceeded)
+
+	log.Info("provision-instance-request-completed", lager.Data{"instance-id": params.InstanceID, "service-id": params.Service.ServiceID})
 
-	return operations.NewCreateServiceInstanceCreated().WithPayload(&brokermodel.DashboardURL{})
-	//TODO create async
+	return operations.NewCreateServiceInstanceCreated().WithPayload(&brokermodel.DashboardURL{DashboardURL: workspaceDashboardURL(log, workspace)})
 }
 
 func deprovisionServiceInstanceHandler(params operations.DeprovisionServiceInstanceParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "deprovision")
 
-	servID, err := getServiceAfterLogin(brokerCsm, brokerConfigProvider, params.ServiceID)
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, params.ServiceID)
+	client = withRequestContext(params.HTTPRequest, client)
 
 	if err != nil {
-		brokerLogger.Info("deprovision-service-error", lager.Data{"error": err.Error()})
+		log.Info("deprovision-service-error", lager.Data{"error": err.Error()})
 		return operations.NewDeprovisionServiceInstanceDefault(401).WithPayload(getBrokerError(err.Error()))
 	}
 
-	if servID == nil {
-		brokerLogger.Info("deprovision-service-not-in-catalog", lager.Data{"service-id": params.ServiceID})
+	if instance == nil {
+		log.Info("deprovision-service-not-in-catalog", lager.Data{"service-id": params.ServiceID})
 		return operations.NewDeprovisionServiceInstanceDefault(404).WithPayload(getBrokerError(params.ServiceID + " not found"))
 	}
 
-	exists, isNoop, err := brokerCsm.WorkspaceExists(params.InstanceID)
+	exists, isNoop, err := client.WorkspaceExists(params.InstanceID)
 
 	if err != nil {
-		brokerLogger.Info("deprovision-service-error", lager.Data{"error": err.Error()})
+		log.Info("deprovision-service-error", lager.Data{"error": err.Error()})
 		return operations.NewDeprovisionServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
 	if !exists && !isNoop {
-		brokerLogger.Info("deprovision-service-missing", lager.Data{"instance-id": params.InstanceID})
+		log.Info("deprovision-service-missing", lager.Data{"instance-id": params.InstanceID})
 		return operations.NewDeprovisionServiceInstanceDefault(404).WithPayload(getBrokerError("No bind with this name found"))
 	}
 
-	err = brokerCsm.DeleteWorkspace(params.InstanceID)
+	if params.AcceptsIncomplete != nil && *params.AcceptsIncomplete {
+		instanceID := params.InstanceID
+		data := lager.Data{"instance-id": instanceID, "service-id": params.ServiceID}
+
+		started := brokerOperations.startIfIdle(instanceID, deprovisionOperation, params.ServiceID, func() error {
+			setServiceInstanceState(log, instanceID, config.StateInProgress)
+
+			err := client.DeleteWorkspace(instanceID)
+			if err != nil {
+				log.Error("async-deprovision-instance-failed", err, data)
+				setServiceInstanceState(log, instanceID, config.StateFailed)
+				return err
+			}
+			log.Info("async-deprovision-instance-completed", data)
+			unregisterServiceInstance(log, instanceID)
+			return nil
+		})
+		if !started {
+			log.Info("deprovision-service-instance-in-progress", data)
+			return operations.NewDeprovisionServiceInstanceUnprocessableEntity().WithPayload(concurrencyError("Another operation for this service instance is in progress"))
+		}
+
+		log.Info("deprovision-service-instance-request-accepted", data)
+
+		return operations.NewDeprovisionServiceInstanceAccepted().WithPayload(map[string]interface{}{"operation": deprovisionOperation})
+	}
+
+	err = client.DeleteWorkspace(params.InstanceID)
 
 	if err != nil {
-		brokerLogger.Info("deprovision-service-error", lager.Data{"error": err.Error()})
+		log.Info("deprovision-service-error", lager.Data{"error": err.Error()})
 		return operations.NewDeprovisionServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
-	brokerLogger.Info("deprovision-service-instance-request-completed", lager.Data{"instance-id": params.InstanceID, "service-id": params.ServiceID})
+	unregisterServiceInstance(log, params.InstanceID)
+
+	log.Info("deprovision-service-instance-request-completed", lager.Data{"instance-id": params.InstanceID, "service-id": params.ServiceID})
 
 	return operations.NewDeprovisionServiceInstanceOK().WithPayload(map[string]interface{}{})
 }
 
 func serviceBindHandler(params operations.ServiceBindParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "bind")
 
-	servID, err := getServiceAfterLogin(brokerCsm, brokerConfigProvider, params.Binding.ServiceID)
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, params.Binding.ServiceID)
+	client = withRequestContext(params.HTTPRequest, client)
 
 	if err != nil {
-		brokerLogger.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		log.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
 		return operations.NewServiceBindDefault(401).WithPayload(getBrokerError(err.Error()))
 	}
 
 	//if no service with this ID was found we send not found HTTP header
-	if servID == nil {
-		brokerLogger.Info("generate-credentials-service-not-in-catalog", lager.Data{"service-id": params.Binding.ServiceID})
+	if instance == nil {
+		log.Info("generate-credentials-service-not-in-catalog", lager.Data{"service-id": params.Binding.ServiceID})
 		return operations.NewServiceBindDefault(404).WithPayload(getBrokerError(params.Binding.ServiceID + " not found"))
 	}
 
-	exists, isNoop, err := brokerCsm.ConnectionExists(params.InstanceID, params.BindingID)
+	dial, err := getPlanDial(brokerConfigProvider, params.Binding.PlanID, params.Binding.ServiceID)
+
+	if err != nil {
+		log.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		return operations.NewServiceBindDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if dial == nil {
+		log.Info("generate-credentials-plan-not-found", lager.Data{"plan-id": params.Binding.PlanID, "service-id": params.Binding.ServiceID})
+		return operations.NewServiceBindDefault(400).WithPayload(getBrokerError("Plan " + params.Binding.PlanID + " not found for service " + params.Binding.ServiceID))
+	}
 
+	violations, err := validateParameters(bindingCreateSchema(dial.Plan), params.Binding.Parameters)
 	if err != nil {
-		brokerLogger.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		log.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		return operations.NewServiceBindDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+	if violations != "" {
+		log.Info("generate-credentials-invalid-parameters", lager.Data{"plan-id": params.Binding.PlanID, "violations": violations})
+		return operations.NewServiceBindDefault(400).WithPayload(getBrokerError(violations))
+	}
+
+	if brokerOperations.pending(params.BindingID, bindOperation) {
+		log.Info("generate-credentials-in-progress", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		return operations.NewServiceBindAccepted().WithPayload(map[string]interface{}{"operation": bindOperation})
+	}
+
+	if brokerOperations.cleaningUp(params.BindingID) {
+		log.Info("generate-credentials-cleanup-in-progress", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		return operations.NewServiceBindConflict().WithPayload(map[string]interface{}{})
+	}
+
+	exists, isNoop, err := client.ConnectionExists(params.InstanceID, params.BindingID)
+
+	if err != nil {
+		log.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
 		return operations.NewServiceBindDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
 	//if it already exists we send it the Conflict - 409 HTTP header
 	if exists && !isNoop {
-		brokerLogger.Info("generate-credentials-service-allready exists", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		log.Info("generate-credentials-service-allready exists", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
 		return operations.NewServiceBindConflict().WithPayload(map[string]interface{}{})
 	}
 
-	results, err := brokerCsm.CreateConnection(params.InstanceID, params.BindingID)
+	if params.AcceptsIncomplete != nil && *params.AcceptsIncomplete && supportsAsyncBindings(params.HTTPRequest) {
+		instanceID := params.InstanceID
+		bindingID := params.BindingID
+		details := connectionDetails(params.Binding, dial)
+		data := lager.Data{"instance-id": instanceID, "binding-id": bindingID, "service-id": params.Binding.ServiceID}
+
+		binding := params.Binding
+
+		started := brokerOperations.startIfIdle(bindingID, bindOperation, params.Binding.ServiceID, func() error {
+			registerServiceBinding(log, instanceID, bindingID, binding, config.StateInProgress)
+
+			results, err := client.CreateConnection(instanceID, bindingID, details)
+			if err != nil {
+				log.Error("async-generate-credentials-failed", err, data)
+				setServiceBindingState(log, bindingID, config.StateFailed)
+				mitigateOrphanConnection(log, client, instanceID, bindingID, err)
+				return err
+			}
+			_, err = newBindingResponse(instance, results)
+			if err != nil {
+				log.Error("async-generate-credentials-invalid", err, data)
+				setServiceBindingState(log, bindingID, config.StateFailed)
+				removeOrphanConnection(log, client, instanceID, bindingID, err)
+				return err
+			}
+			log.Info("async-generate-credentials-completed", data)
+			setServiceBindingState(log, bindingID, config.StateSucceeded)
+			return nil
+		})
+		if !started {
+			log.Info("generate-credentials-in-progress", data)
+			return operations.NewServiceBindAccepted().WithPayload(map[string]interface{}{"operation": bindOperation})
+		}
+
+		log.Info("generate-credentials-request-accepted", data)
+
+		return operations.NewServiceBindAccepted().WithPayload(map[string]interface{}{"operation": bindOperation})
+	}
+
+	results, err := client.CreateConnection(params.InstanceID, params.BindingID, connectionDetails(params.Binding, dial))
 
 	if err != nil {
-		brokerLogger.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		log.Info("generate-credentials-service-error", lager.Data{"error": err.Error()})
+		mitigateOrphanConnection(log, client, params.InstanceID, params.BindingID, err)
 		return operations.NewServiceBindDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
-	brokerLogger.Info("received-result", lager.Data{"result": results})
-	bindingResponse := brokermodel.BindingResponse{}
+	log.Info("received-result", lager.Data{"result": results})
+	bindingResponse, err := newBindingResponse(instance, results)
 
-	if routingService(servID.Requires) == true {
-		routeInfo := results.(map[string]interface{})
-		bindingResponse.RouteServiceURL = routeInfo["route_service_url"].(string)
-	} else {
-		bindingResponse.Credentials = results
+	if err != nil {
+		log.Info("generate-credentials-invalid-result", lager.Data{"error": err.Error()})
+		removeOrphanConnection(log, client, params.InstanceID, params.BindingID, err)
+		return operations.NewServiceBindDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
-	brokerLogger.Info("generate-credentials-request-completed", lager.Data{"binding-id": params.BindingID, "service-id": params.Binding.ServiceID})
+	registerServiceBinding(log, params.InstanceID, params.BindingID, params.Binding, config.StateSucceeded)
+
+	log.Info("generate-credentials-request-completed", lager.Data{"binding-id": params.BindingID, "service-id": params.Binding.ServiceID})
 
-	return operations.NewServiceBindCreated().WithPayload(&bindingResponse)
+	return operations.NewServiceBindCreated().WithPayload(bindingResponse)
 
 }
 
-func routingService(serviceRequires []string) bool {
-	for _, req := range serviceRequires {
-		if req == "route_forwarding" {
-			return true
-		}
+func getServiceInstanceHandler(params operations.GetServiceInstanceParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "get-instance")
+
+	if brokerOperations.pending(params.InstanceID, provisionOperation) {
+		log.Info("get-service-instance-in-progress", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewGetServiceInstanceDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Service instance %s is being provisioned", params.InstanceID)))
+	}
+
+	instance, _, workspace, err := getWorkspaceClient(params.HTTPRequest, params.ServiceID, params.InstanceID)
+
+	if err != nil {
+		log.Info("get-service-instance-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if instance == nil {
+		log.Info("get-service-instance-missing", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewGetServiceInstanceDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Service instance %s not found", params.InstanceID)))
+	}
+
+	resource := brokermodel.ServiceInstanceResource{ServiceID: instance.Service.ID}
+	if planID, ok := workspace.Details["plan_id"].(string); ok {
+		resource.PlanID = planID
+	}
+	resource.DashboardURL = workspaceDashboardURL(log, workspace)
+	if parameters, ok := workspace.Details["parameters"].(map[string]interface{}); ok {
+		resource.Parameters = parameters
+	}
+
+	log.Info("get-service-instance-completed", lager.Data{"instance-id": params.InstanceID, "service-id": resource.ServiceID})
+
+	return operations.NewGetServiceInstanceOK().WithPayload(&resource)
+}
+
+func getServiceBindingHandler(params operations.GetServiceBindingParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "get-binding")
+
+	if brokerOperations.pending(params.BindingID, bindOperation) {
+		log.Info("get-service-binding-in-progress", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		return operations.NewGetServiceBindingDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Binding %s is being created", params.BindingID)))
+	}
+
+	instance, client, _, err := getWorkspaceClient(params.HTTPRequest, params.ServiceID, params.InstanceID)
+
+	if err != nil {
+		log.Info("get-service-binding-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceBindingDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if instance == nil {
+		log.Info("get-service-binding-instance-missing", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewGetServiceBindingDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Service instance %s not found", params.InstanceID)))
+	}
+
+	connection, err := client.GetConnection(params.InstanceID, params.BindingID)
+
+	if err != nil {
+		log.Info("get-service-binding-error", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceBindingDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if connection == nil {
+		log.Info("get-service-binding-missing", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		return operations.NewGetServiceBindingDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Binding %s not found", params.BindingID)))
+	}
+
+	details := connection.Details
+	if details == nil {
+		details = map[string]interface{}{}
 	}
-	return false
+
+	bindingResponse, err := newBindingResponse(instance, details)
+	if err != nil {
+		log.Info("get-service-binding-invalid", lager.Data{"error": err.Error()})
+		return operations.NewGetServiceBindingDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	log.Info("get-service-binding-completed", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+
+	return operations.NewGetServiceBindingOK().WithPayload(bindingResponse)
 }
 
 func serviceUnbindHandler(params operations.ServiceUnbindParams, principal interface{}) middleware.Responder {
+	log := requestLogger(params.HTTPRequest, "unbind")
 
-	servID, err := getServiceAfterLogin(brokerCsm, brokerConfigProvider, params.ServiceID)
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, params.ServiceID)
+	client = withRequestContext(params.HTTPRequest, client)
 
 	if err != nil {
-		brokerLogger.Info("unbind-instance-error", lager.Data{"error": err.Error()})
+		log.Info("unbind-instance-error", lager.Data{"error": err.Error()})
 		return operations.NewServiceUnbindDefault(401).WithPayload(getBrokerError(err.Error()))
 	}
 	//if no service with this ID was found we send Gone HTTP header
-	if servID == nil {
-		brokerLogger.Info("unbind-instance-not-in-catalog", lager.Data{"service-id": params.ServiceID})
+	if instance == nil {
+		log.Info("unbind-instance-not-in-catalog", lager.Data{"service-id": params.ServiceID})
 		return operations.NewServiceUnbindDefault(404).WithPayload(getBrokerError(params.ServiceID + " not found"))
 	}
 
-	exists, isNoop, err := brokerCsm.ConnectionExists(params.InstanceID, params.BindingID)
+	exists, isNoop, err := client.ConnectionExists(params.InstanceID, params.BindingID)
 
 	if err != nil {
-		brokerLogger.Info("unbind-instance-error", lager.Data{"error": err.Error()})
+		log.Info("unbind-instance-error", lager.Data{"error": err.Error()})
 		return operations.NewServiceUnbindDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 	//if it does not exist we send it the Not found 404 HTTP header
 	if !exists && !isNoop {
-		brokerLogger.Info("unbind-instance-missing", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
+		log.Info("unbind-instance-missing", lager.Data{"instance-id": params.InstanceID, "binding-id": params.BindingID})
 		return operations.NewServiceUnbindDefault(404).WithPayload(getBrokerError(fmt.Sprintf("Binding %s not found", params.BindingID)))
 	}
 
-	err = brokerCsm.DeleteConnection(params.InstanceID, params.BindingID)
+	if params.AcceptsIncomplete != nil && *params.AcceptsIncomplete && supportsAsyncBindings(params.HTTPRequest) {
+		instanceID := params.InstanceID
+		bindingID := params.BindingID
+		data := lager.Data{"instance-id": instanceID, "binding-id": bindingID, "service-id": params.ServiceID}
+
+		started := brokerOperations.startIfIdle(bindingID, unbindOperation, params.ServiceID, func() error {
+			setServiceBindingState(log, bindingID, config.StateInProgress)
+
+			err := client.DeleteConnection(instanceID, bindingID)
+			if err != nil {
+				log.Error("async-unbind-instance-failed", err, data)
+				setServiceBindingState(log, bindingID, config.StateFailed)
+				return err
+			}
+			log.Info("async-unbind-instance-completed", data)
+			unregisterServiceBinding(log, bindingID)
+			return nil
+		})
+		if !started {
+			log.Info("unbind-instance-in-progress", data)
+			return operations.NewServiceUnbindDefault(422).WithPayload(getBrokerError("Another operation for this service binding is in progress"))
+		}
+
+		log.Info("unbind-instance-accepted", data)
+
+		return operations.NewServiceUnbindAccepted().WithPayload(map[string]interface{}{"operation": unbindOperation})
+	}
+
+	err = client.DeleteConnection(params.InstanceID, params.BindingID)
 
 	if err != nil {
-		brokerLogger.Info("unbind-instance-error", lager.Data{"error": err.Error()})
+		log.Info("unbind-instance-error", lager.Data{"error": err.Error()})
 		return operations.NewServiceUnbindDefault(500).WithPayload(getBrokerError(err.Error()))
 	}
 
-	brokerLogger.Info("unbind-instance-completed", lager.Data{"binding-id": params.BindingID})
+	unregisterServiceBinding(log, params.BindingID)
+
+	log.Info("unbind-instance-completed", lager.Data{"binding-id": params.BindingID})
 
 	return operations.NewServiceUnbindOK().WithPayload(map[string]interface{}{})
 }
 
 func updateServiceInstanceHandler(params operations.UpdateServiceInstanceParams, principal interface{}) middleware.Responder {
-	brokerLogger.Info("unpdate-service-instance-not-implemented", lager.Data{"instance-id": params.InstanceID})
-	return middleware.NotImplemented("operation operations.UpdateServiceInstance has not yet been implemented")
+	log := requestLogger(params.HTTPRequest, "update")
+
+	instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, params.Plan.ServiceID)
+	client = withRequestContext(params.HTTPRequest, client)
+
+	if err != nil {
+		log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+		return operations.NewUpdateServiceInstanceDefault(401).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if instance == nil {
+		log.Info("update-service-instance-not-in-catalog", lager.Data{"service-id": params.Plan.ServiceID})
+		return operations.NewUpdateServiceInstanceDefault(404).WithPayload(getBrokerError(params.Plan.ServiceID + " not found"))
+	}
+
+	var dial *config.Dial
+	if isPlanChange(params.Plan) {
+		if !instance.Service.PlanUpdateable {
+			log.Info("update-service-instance-plan-not-updateable", lager.Data{"instance-id": params.InstanceID, "service-id": params.Plan.ServiceID})
+			return operations.NewUpdateServiceInstanceUnprocessableEntity().WithPayload(&brokermodel.AsyncError{
+				Error:       "PlanChangeNotSupported",
+				Description: "The service " + params.Plan.ServiceID + " does not support plan changes",
+			})
+		}
+
+		dial, err = getPlanDial(brokerConfigProvider, params.Plan.PlanID, params.Plan.ServiceID)
+		if err != nil {
+			log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+			return operations.NewUpdateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+		}
+
+		if dial == nil {
+			log.Info("update-service-instance-invalid-plan", lager.Data{"plan-id": params.Plan.PlanID, "service-id": params.Plan.ServiceID})
+			return operations.NewUpdateServiceInstanceUnprocessableEntity().WithPayload(&brokermodel.AsyncError{
+				Error:       "PlanChangeNotSupported",
+				Description: "The plan " + params.Plan.PlanID + " does not belong to the service " + params.Plan.ServiceID,
+			})
+		}
+	}
+
+	if params.Plan.Parameters != nil {
+		schemaDial := dial
+		if planID := currentPlanID(params.InstanceID, params.Plan); schemaDial == nil && planID != "" {
+			schemaDial, err = getPlanDial(brokerConfigProvider, planID, params.Plan.ServiceID)
+			if err != nil {
+				log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+				return operations.NewUpdateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+			}
+		}
+
+		if schemaDial != nil {
+			violations, err := validateParameters(instanceUpdateSchema(schemaDial.Plan), params.Plan.Parameters)
+			if err != nil {
+				log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+				return operations.NewUpdateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+			}
+			if violations != "" {
+				log.Info("update-service-instance-invalid-parameters", lager.Data{"instance-id": params.InstanceID, "violations": violations})
+				return operations.NewUpdateServiceInstanceDefault(400).WithPayload(getBrokerError(violations))
+			}
+		}
+	}
+
+	if operation := brokerOperations.get(params.InstanceID); operation != nil && !operation.done {
+		log.Info("update-service-instance-in-progress", lager.Data{"instance-id": params.InstanceID, "operation": operation.kind})
+		return operations.NewUpdateServiceInstanceUnprocessableEntity().WithPayload(concurrencyError("Another operation for this service instance is in progress"))
+	}
+
+	exists, isNoop, err := client.WorkspaceExists(params.InstanceID)
+
+	if err != nil {
+		log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+		return operations.NewUpdateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	if !exists && !isNoop {
+		log.Info("update-service-instance-missing", lager.Data{"instance-id": params.InstanceID})
+		return operations.NewUpdateServiceInstanceDefault(404).WithPayload(getBrokerError("Workspace " + params.InstanceID + " not found"))
+	}
+
+	details := updateDetails(params.Plan, dial)
+
+	if params.AcceptsIncomplete != nil && *params.AcceptsIncomplete {
+		instanceID := params.InstanceID
+		data := lager.Data{"instance-id": instanceID, "service-id": params.Plan.ServiceID, "plan-id": params.Plan.PlanID}
+
+		plan := params.Plan
+		started := brokerOperations.startIfIdle(instanceID, updateOperation, params.Plan.ServiceID, func() error {
+			setServiceInstanceState(log, instanceID, config.StateInProgress)
+
+			err := client.UpdateWorkspace(instanceID, details)
+			if err != nil {
+				log.Error("async-update-instance-failed", err, data)
+				setServiceInstanceState(log, instanceID, config.StateFailed)
+				return err
+			}
+			log.Info("async-update-instance-completed", data)
+			updateServiceInstancePlan(log, instanceID, plan)
+			return nil
+		})
+		if !started {
+			log.Info("update-service-instance-in-progress", data)
+			return operations.NewUpdateServiceInstanceUnprocessableEntity().WithPayload(concurrencyError("Another operation for this service instance is in progress"))
+		}
+
+		log.Info("update-service-instance-request-accepted", data)
+
+		return operations.NewUpdateServiceInstanceAccepted().WithPayload(map[string]interface{}{"operation": updateOperation})
+	}
+
+	err = client.UpdateWorkspace(params.InstanceID, details)
+
+	if err != nil {
+		log.Info("update-service-instance-error", lager.Data{"error": err.Error()})
+		return operations.NewUpdateServiceInstanceDefault(500).WithPayload(getBrokerError(err.Error()))
+	}
+
+	updateServiceInstancePlan(log, params.InstanceID, params.Plan)
+
+	log.Info("update-service-instance-request-completed", lager.Data{"instance-id": params.InstanceID, "service-id": params.Plan.ServiceID, "plan-id": params.Plan.PlanID})
+
+	return operations.NewUpdateServiceInstanceOK().WithPayload(map[string]interface{}{})
 }
 
 func basicAuth(user string, pass string) (interface{}, error) {
-	conf, err := brokerConfigProvider.LoadConfiguration()
+	valid, err := brokerAuth.authenticate(user, pass)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if conf.BrokerAPI.Credentials.Username == user &&
-		conf.BrokerAPI.Credentials.Password == pass {
+	if valid {
 		return true, nil
 	}
 
-	brokerLogger.Info("Invalid username/pass", lager.Data{"message": "invalid username/password combination"})
-
 	return nil, fmt.Errorf("Not authorized")
 }
 
 //ConfigureAPI is the function that defines what functions will handle the requestss
-func ConfigureAPI(api *operations.BrokerAPI, csm csm.CSM, configProvider config.Provider, logger lager.Logger) http.Handler {
+func ConfigureAPI(api *operations.BrokerAPI, csmClients csm.ClientCache, configProvider config.Provider, logger lager.Logger) http.Handler {
 
-	brokerCsm = csm
+	brokerCsmClients = csmClients
 	brokerLogger = logger
 	brokerConfigProvider = configProvider
+	brokerOperations = newAsyncOperations(logger)
+	brokerAuth = newBrokerCredentials()
+
+	conf, err := configProvider.LoadConfiguration()
+	if err != nil {
+		logger.Error("load-configuration-failed", err)
+	} else {
+		brokerMinAPIVersion = conf.APIVersion
+	}
 
 	api.ServeError = errors.ServeError
 	api.JSONConsumer = runtime.JSONConsumer()
@@ -288,6 +864,15 @@ func ConfigureAPI(api *operations.BrokerAPI, csm csm.CSM, configProvider config.
 
 	api.BasicAuth = basicAuth
 
+	api.GetServiceInstanceHandler =
+		operations.GetServiceInstanceHandlerFunc(getServiceInstanceHandler)
+
+	api.GetServiceBindingHandler =
+		operations.GetServiceBindingHandlerFunc(getServiceBindingHandler)
+
+	api.GetServiceBindingLastOperationHandler =
+		operations.GetServiceBindingLastOperationHandlerFunc(bindingLastOperationHandler)
+
 	api.GetServiceInstancesInstanceIDLastOperationHandler =
 		operations.GetServiceInstancesInstanceIDLastOperationHandlerFunc(idLastOperationHandler)
 
@@ -309,32 +894,216 @@ func ConfigureAPI(api *operations.BrokerAPI, csm csm.CSM, configProvider config.
 	api.UpdateServiceInstanceHandler =
 		operations.UpdateServiceInstanceHandlerFunc(updateServiceInstanceHandler)
 
-	api.ServerShutdown = func() {}
+	api.ServerShutdown = func() {
+		background.Wait()
+	}
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
-func getServiceAfterLogin(csm csm.CSM, configProvider config.Provider, serviceID string) (*brokermodel.CatalogService, error) {
+func getServiceClient(clients csm.ClientCache, configProvider config.Provider, serviceID string) (*config.Instance, csm.CSM, error) {
 
 	conf, err := configProvider.LoadConfiguration()
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	for _, driverInstance := range conf.Instances {
+	for driverInstanceID, driverInstance := range conf.Instances {
 		if driverInstance.Service.ID == serviceID {
 			if driverInstance.TargetURL != "" {
-				err = csm.Login(driverInstance.TargetURL, driverInstance.AuthenticationKey, driverInstance.CaCert, driverInstance.SkipSsl)
+				client, err := clients.GetClient(driverInstanceID, instanceEndpoint(driverInstance))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
-				return &driverInstance.Service, nil
+				instance := driverInstance
+				return &instance, client, nil
 			}
 		}
 	}
 
-	return nil, nil
+	return nil, nil, nil
+}
+
+// workspaceDetails builds the details sent to the CSM when a workspace is created, so that
+// sidecars can tailor the instance to the plan and parameters requested by the user
+func workspaceDetails(service *brokermodel.Service, dial *config.Dial) map[string]interface{} {
+	details := make(map[string]interface{})
+	if service == nil {
+		return details
+	}
+
+	if service.Parameters != nil {
+		details["parameters"] = service.Parameters
+	}
+	if service.OrganizationGUID != "" {
+		details["organization_guid"] = service.OrganizationGUID
+	}
+	if service.SpaceGUID != "" {
+		details["space_guid"] = service.SpaceGUID
+	}
+	if service.PlanID != "" {
+		details["plan_id"] = service.PlanID
+	}
+	if dial != nil && dial.Configuration != nil {
+		details["configuration"] = dial.Configuration
+	}
+
+	return details
+}
+
+func instanceEndpoint(driverInstance config.Instance) csm.Endpoint {
+	return csm.Endpoint{
+		Name:              driverInstance.Name,
+		TargetURL:         driverInstance.TargetURL,
+		AuthenticationKey: driverInstance.AuthenticationKey,
+		CaCert:            driverInstance.CaCert,
+		SkipSSLValidation: driverInstance.SkipSsl,
+	}
+}
+
+// getWorkspaceClient returns the driver instance and client of the CSM holding workspaceID;
+// the service id is optional, without it every driver instance is asked for the workspace
+func getWorkspaceClient(r *http.Request, serviceID *string, workspaceID string) (*config.Instance, csm.CSM, *models.ServiceManagerWorkspaceResponse, error) {
+	if serviceID != nil {
+		instance, client, err := getServiceClient(brokerCsmClients, brokerConfigProvider, *serviceID)
+		if err != nil || instance == nil {
+			return nil, nil, nil, err
+		}
+		client = withRequestContext(r, client)
+
+		workspace, err := client.GetWorkspace(workspaceID)
+		if err != nil || workspace == nil {
+			return nil, nil, nil, err
+		}
+
+		return instance, client, workspace, nil
+	}
+
+	conf, err := brokerConfigProvider.LoadConfiguration()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for driverInstanceID, driverInstance := range conf.Instances {
+		if driverInstance.TargetURL == "" {
+			continue
+		}
+
+		client, err := brokerCsmClients.GetClient(driverInstanceID, instanceEndpoint(driverInstance))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		client = withRequestContext(r, client)
+
+		workspace, err := client.GetWorkspace(workspaceID)
+		if err != nil {
+			brokerLogger.Error("get-workspace-failed", err, lager.Data{"driver-instance-id": driverInstanceID, "instance-id": workspaceID})
+			continue
+		}
+
+		// sidecars that do not keep track of workspaces answer for any id
+		if workspace != nil && !isNoopWorkspace(workspace) {
+			instance := driverInstance
+			return &instance, client, workspace, nil
+		}
+	}
+
+	return nil, nil, nil, nil
+}
+
+// getPlanDial resolves planID to the dial offering it, a nil dial is returned when the plan
+// is not offered by the driver instance serving serviceID
+func getPlanDial(configProvider config.Provider, planID string, serviceID string) (*config.Dial, error) {
+	plan, dialID, planInstanceID, err := configProvider.GetPlan(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, nil
+	}
+
+	_, serviceInstanceID, err := configProvider.GetService(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if planInstanceID != serviceInstanceID {
+		return nil, nil
+	}
+
+	dial, _, err := configProvider.GetDial(dialID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dial, nil
+}
+
+// isPlanChange reports whether an update request moves the instance to a different plan
+func isPlanChange(plan *brokermodel.ServicePlan) bool {
+	if plan.PlanID == "" {
+		return false
+	}
+	return plan.PreviousValues == nil || plan.PreviousValues.PlanID != plan.PlanID
+}
+
+// updateDetails builds the details sent to the CSM when a workspace is updated
+func updateDetails(plan *brokermodel.ServicePlan, dial *config.Dial) map[string]interface{} {
+	details := make(map[string]interface{})
+
+	if plan.Parameters != nil {
+		details["parameters"] = plan.Parameters
+	}
+	if plan.PlanID != "" {
+		details["plan_id"] = plan.PlanID
+	}
+	if dial != nil && dial.Configuration != nil {
+		details["configuration"] = dial.Configuration
+	}
+	if plan.PreviousValues != nil {
+		details["previous_values"] = map[string]interface{}{
+			"plan_id":         plan.PreviousValues.PlanID,
+			"service_id":      plan.PreviousValues.ServiceID,
+			"organization_id": plan.PreviousValues.OrganizationID,
+			"space_id":        plan.PreviousValues.SpaceID,
+		}
+	}
+
+	return details
+}
+
+// connectionDetails builds the details sent to the CSM when a connection is created, so that
+// sidecars can issue credentials scoped to the parameters and application of the binding
+func connectionDetails(binding *brokermodel.Binding, dial *config.Dial) map[string]interface{} {
+	details := make(map[string]interface{})
+	if binding == nil {
+		return details
+	}
+
+	if binding.Parameters != nil {
+		details["parameters"] = binding.Parameters
+	}
+	if binding.AppGUID != "" {
+		details["app_guid"] = binding.AppGUID
+	}
+	if binding.PlanID != "" {
+		details["plan_id"] = binding.PlanID
+	}
+	if dial != nil && dial.Configuration != nil {
+		details["configuration"] = dial.Configuration
+	}
+	if binding.BindResource != nil {
+		bindResource := make(map[string]interface{})
+		if binding.BindResource.AppGUID != "" {
+			bindResource["app_guid"] = binding.BindResource.AppGUID
+		}
+		if binding.BindResource.Route != "" {
+			bindResource["route"] = binding.BindResource.Route
+		}
+		details["bind_resource"] = bindResource
+	}
+
+	return details
 }
 
 // The TLS configuration before HTTPS server starts.
@@ -351,5 +1120,12 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return handler
+	handler = httpmiddleware.Recovery(brokerLogger, apiVersionMiddleware(originatingIdentityMiddleware(handler)), func(w http.ResponseWriter, message string) {
+		writeBrokerError(w, http.StatusInternalServerError, message)
+	})
+	templates, err := metrics.SpecPathTemplates(SwaggerJSON)
+	if err != nil {
+		brokerLogger.Error("path-templates-failed", err)
+	}
+	return httpmiddleware.RequestIDs(httpmiddleware.AccessLog(brokerLogger, httpmiddleware.Metrics("broker", templates, handler)))
 }
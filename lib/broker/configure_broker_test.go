@@ -0,0 +1,137 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/config/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	parameters := map[string]interface{}{"size": "large", "replicas": float64(3)}
+
+	cases := []struct {
+		name    string
+		service *brokermodel.Service
+		details map[string]interface{}
+	}{
+		{"no request", nil, map[string]interface{}{}},
+		{"empty request", &brokermodel.Service{}, map[string]interface{}{}},
+		{"plan only", &brokermodel.Service{PlanID: "plan"}, map[string]interface{}{"plan_id": "plan"}},
+		{"full request", &brokermodel.Service{
+			ServiceID:        "service",
+			PlanID:           "plan",
+			OrganizationGUID: "org",
+			SpaceGUID:        "space",
+			Parameters:       parameters,
+		}, map[string]interface{}{
+			"plan_id":           "plan",
+			"organization_guid": "org",
+			"space_guid":        "space",
+			"parameters":        parameters,
+		}},
+		{"empty parameters", &brokermodel.Service{Parameters: map[string]interface{}{}},
+			map[string]interface{}{"parameters": map[string]interface{}{}}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.details, workspaceDetails(c.service, nil), c.name)
+	}
+
+	configuration := json.RawMessage(`{"memory":"2G"}`)
+	details := workspaceDetails(&brokermodel.Service{PlanID: "plan"}, &config.Dial{Configuration: &configuration})
+	assert.Equal(map[string]interface{}{"plan_id": "plan", "configuration": &configuration}, details)
+	assert.Equal(map[string]interface{}{"plan_id": "plan"}, workspaceDetails(&brokermodel.Service{PlanID: "plan"}, &config.Dial{}))
+	assert.Equal(map[string]interface{}{}, workspaceDetails(nil, &config.Dial{Configuration: &configuration}))
+}
+
+func TestConnectionDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	parameters := map[string]interface{}{"role": "read-only"}
+
+	cases := []struct {
+		name    string
+		binding *brokermodel.Binding
+		details map[string]interface{}
+	}{
+		{"no request", nil, map[string]interface{}{}},
+		{"empty request", &brokermodel.Binding{}, map[string]interface{}{}},
+		{"application binding", &brokermodel.Binding{
+			ServiceID:    "service",
+			PlanID:       "plan",
+			AppGUID:      "app",
+			Parameters:   parameters,
+			BindResource: &brokermodel.BindResource{AppGUID: "app"},
+		}, map[string]interface{}{
+			"plan_id":       "plan",
+			"app_guid":      "app",
+			"parameters":    parameters,
+			"bind_resource": map[string]interface{}{"app_guid": "app"},
+		}},
+		{"route binding", &brokermodel.Binding{
+			PlanID:       "plan",
+			BindResource: &brokermodel.BindResource{Route: "app.example.com"},
+		}, map[string]interface{}{
+			"plan_id":       "plan",
+			"bind_resource": map[string]interface{}{"route": "app.example.com"},
+		}},
+		{"empty bind resource", &brokermodel.Binding{BindResource: &brokermodel.BindResource{}},
+			map[string]interface{}{"bind_resource": map[string]interface{}{}}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(c.details, connectionDetails(c.binding, nil), c.name)
+	}
+
+	configuration := json.RawMessage(`{"memory":"2G"}`)
+	details := connectionDetails(&brokermodel.Binding{PlanID: "plan"}, &config.Dial{Configuration: &configuration})
+	assert.Equal(map[string]interface{}{"plan_id": "plan", "configuration": &configuration}, details)
+}
+
+func TestGetPlanDial(t *testing.T) {
+	assert := assert.New(t)
+
+	configuration := json.RawMessage(`{"memory":"2G"}`)
+	dial := &config.Dial{Configuration: &configuration, Plan: brokermodel.Plan{ID: "plan"}}
+	otherDial := &config.Dial{Plan: brokermodel.Plan{ID: "other-plan"}}
+
+	provider := new(mocks.Provider)
+	provider.On("GetPlan", "plan").Return(&dial.Plan, "dial", "driver", nil)
+	provider.On("GetPlan", "other-plan").Return(&otherDial.Plan, "other-dial", "other-driver", nil)
+	provider.On("GetPlan", "missing-plan").Return(nil, "", "", nil)
+	provider.On("GetPlan", "broken-plan").Return(nil, "", "", errors.New("plans unavailable"))
+	provider.On("GetService", "service").Return(&brokermodel.CatalogService{ID: "service"}, "driver", nil)
+	provider.On("GetService", "missing-service").Return(nil, "", nil)
+	provider.On("GetService", "broken-service").Return(nil, "", errors.New("services unavailable"))
+	provider.On("GetDial", "dial").Return(dial, "driver", nil)
+	provider.On("GetDial", "other-dial").Return(otherDial, "other-driver", nil)
+
+	cases := []struct {
+		name      string
+		planID    string
+		serviceID string
+		dial      *config.Dial
+		failed    bool
+	}{
+		{"plan of the service", "plan", "service", dial, false},
+		{"plan of another driver endpoint", "other-plan", "service", nil, false},
+		{"unknown plan", "missing-plan", "service", nil, false},
+		{"unknown service", "plan", "missing-service", nil, false},
+		{"plan lookup failure", "broken-plan", "service", nil, true},
+		{"service lookup failure", "plan", "broken-service", nil, true},
+	}
+
+	for _, c := range cases {
+		result, err := getPlanDial(provider, c.planID, c.serviceID)
+		assert.Equal(c.failed, err != nil, c.name)
+		assert.Equal(c.dial, result, c.name)
+	}
+	provider.AssertNotCalled(t, "GetDial", "other-dial")
+}
@@ -0,0 +1,151 @@
+package broker
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/pivotal-golang/lager"
+)
+
+var (
+	//credentialsRefreshInterval is how long the broker keeps using the accounts it loaded before loading them again
+	credentialsRefreshInterval = 30 * time.Second
+	//credentialsReloadInterval is how long the broker waits after loading the accounts before credentials it
+	//rejected make it load them again, so that callers with wrong passwords can not hammer the configuration
+	credentialsReloadInterval = time.Second
+)
+
+//brokerCredentials keeps the accounts accepted by the broker so that the configuration is not loaded on every request.
+//Credentials that passed the bcrypt comparison are remembered by their digest until the accounts are reloaded, and
+//credentials that did not are checked again against freshly loaded accounts so that a rotated password works at once.
+//The lock only guards this state: the configuration is loaded and bcrypt runs without holding it
+type brokerCredentials struct {
+	sync.Mutex
+	loaded   bool
+	loadedAt time.Time
+	//generation changes whenever the accounts are loaded or forgotten, so that a comparison made against accounts
+	//that were replaced meanwhile is not remembered
+	generation    int
+	accounts      []config.BrokerAccount
+	configured    config.BrokerCredentials
+	authenticated map[[sha256.Size]byte]bool
+}
+
+func newBrokerCredentials() *brokerCredentials {
+	return &brokerCredentials{authenticated: make(map[[sha256.Size]byte]bool)}
+}
+
+//load reads the accounts from the configuration and starts using them, it must be called without the lock held
+func (c *brokerCredentials) load() error {
+	conf, err := brokerConfigProvider.LoadConfiguration()
+	if err != nil {
+		return err
+	}
+	accounts, err := brokerConfigProvider.GetBrokerAccounts()
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.accounts = accounts
+	c.configured = conf.BrokerAPI.Credentials
+	c.authenticated = make(map[[sha256.Size]byte]bool)
+	c.loaded = true
+	c.loadedAt = time.Now()
+	c.generation++
+	return nil
+}
+
+//claimLoad reports whether the accounts were loaded longer than interval ago or not at all, and if so records the
+//load as started so that concurrent requests keep using the current accounts instead of loading them as well
+func (c *brokerCredentials) claimLoad(interval time.Duration) (claimed bool, loaded bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.loaded && time.Since(c.loadedAt) <= interval {
+		return false, true
+	}
+	if c.loaded {
+		c.loadedAt = time.Now()
+	}
+	return true, c.loaded
+}
+
+//current returns the accounts in use and whether the credentials with digest were accepted already
+func (c *brokerCredentials) current(digest [sha256.Size]byte) ([]config.BrokerAccount, config.BrokerCredentials, int, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.accounts, c.configured, c.generation, c.authenticated[digest]
+}
+
+//remember records credentials that were accepted, unless the accounts they were checked against were replaced
+func (c *brokerCredentials) remember(digest [sha256.Size]byte, generation int) {
+	c.Lock()
+	defer c.Unlock()
+
+	if generation == c.generation {
+		c.authenticated[digest] = true
+	}
+}
+
+//forget drops the authenticated credentials and has the accounts loaded again on the next request
+func (c *brokerCredentials) forget() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.authenticated = make(map[[sha256.Size]byte]bool)
+	c.loadedAt = time.Time{}
+	c.generation++
+}
+
+func (c *brokerCredentials) authenticate(username string, password string) (bool, error) {
+	if claimed, loaded := c.claimLoad(credentialsRefreshInterval); claimed {
+		err := c.load()
+		if err != nil {
+			if !loaded {
+				return false, err
+			}
+			// Keep serving with the accounts loaded last
+			brokerLogger.Error("load-broker-accounts-failed", err)
+		}
+	}
+
+	digest := sha256.Sum256([]byte(username + "\x00" + password))
+	accounts, configured, generation, accepted := c.current(digest)
+	if accepted {
+		return true, nil
+	}
+
+	valid := config.AuthenticateBroker(accounts, configured, username, password)
+	if !valid {
+		if claimed, _ := c.claimLoad(credentialsReloadInterval); claimed {
+			err := c.load()
+			if err != nil {
+				brokerLogger.Error("load-broker-accounts-failed", err)
+			} else {
+				accounts, configured, generation, _ = c.current(digest)
+				valid = config.AuthenticateBroker(accounts, configured, username, password)
+			}
+		}
+	}
+
+	if valid {
+		c.remember(digest, generation)
+	} else {
+		brokerLogger.Info("invalid-credentials", lager.Data{"username": username})
+	}
+	return valid, nil
+}
+
+//ForgetCredentials has the broker drop the credentials it accepted and load the broker accounts again, it is called
+//when the accounts are changed so that a rotated password stops being accepted at once
+func ForgetCredentials() {
+	if brokerAuth != nil {
+		brokerAuth.forget()
+	}
+}
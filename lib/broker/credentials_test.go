@@ -0,0 +1,106 @@
+package broker
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/config/mocks"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticateCachesAcceptedCredentials(t *testing.T) {
+	assert := assert.New(t)
+	brokerLogger = lagertest.NewTestLogger("credentials-test")
+
+	previousInterval := credentialsReloadInterval
+	credentialsReloadInterval = time.Hour
+	defer func() { credentialsReloadInterval = previousInterval }()
+
+	hash, err := config.HashBrokerPassword("secret")
+	assert.NoError(err)
+
+	provider := new(mocks.Provider)
+	provider.On("LoadConfiguration").Return(&config.Config{}, nil)
+	provider.On("GetBrokerAccounts").Return([]config.BrokerAccount{{Username: "admin", PasswordHash: hash}}, nil)
+	brokerConfigProvider = provider
+
+	credentials := newBrokerCredentials()
+
+	valid, err := credentials.authenticate("admin", "secret")
+	assert.NoError(err)
+	assert.True(valid)
+
+	valid, err = credentials.authenticate("admin", "secret")
+	assert.NoError(err)
+	assert.True(valid)
+
+	valid, err = credentials.authenticate("admin", "wrong")
+	assert.NoError(err)
+	assert.False(valid)
+	provider.AssertNumberOfCalls(t, "GetBrokerAccounts", 1)
+
+	credentials.forget()
+	valid, err = credentials.authenticate("admin", "secret")
+	assert.NoError(err)
+	assert.True(valid)
+	provider.AssertNumberOfCalls(t, "GetBrokerAccounts", 2)
+}
+
+func TestAuthenticateDoesNotWaitForReloads(t *testing.T) {
+	assert := assert.New(t)
+	brokerLogger = lagertest.NewTestLogger("credentials-test")
+
+	previousInterval := credentialsRefreshInterval
+	defer func() { credentialsRefreshInterval = previousInterval }()
+
+	hash, err := config.HashBrokerPassword("secret")
+	assert.NoError(err)
+
+	var loads int32
+	release := make(chan struct{})
+	provider := new(mocks.Provider)
+	provider.On("LoadConfiguration").Return(&config.Config{}, nil).Run(func(mock.Arguments) {
+		if atomic.AddInt32(&loads, 1) == 2 {
+			<-release
+		}
+	})
+	provider.On("GetBrokerAccounts").Return([]config.BrokerAccount{{Username: "admin", PasswordHash: hash}}, nil)
+	brokerConfigProvider = provider
+
+	credentials := newBrokerCredentials()
+	valid, err := credentials.authenticate("admin", "secret")
+	assert.NoError(err)
+	assert.True(valid)
+
+	credentialsRefreshInterval = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+
+	reloaded := make(chan bool)
+	go func() {
+		valid, _ := credentials.authenticate("admin", "secret")
+		reloaded <- valid
+	}()
+	for atomic.LoadInt32(&loads) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	answered := make(chan bool)
+	go func() {
+		valid, _ := credentials.authenticate("admin", "secret")
+		answered <- valid
+	}()
+
+	select {
+	case valid := <-answered:
+		assert.True(valid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("authentication waited for the reload of the accounts")
+	}
+
+	close(release)
+	assert.True(<-reloaded)
+}
@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"net/url"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//dashboardURLDetail is the workspace detail in which a sidecar returns the dashboard URL of a service instance
+const dashboardURLDetail string = "dashboard_url"
+
+//workspaceDashboardURL returns the dashboard URL a sidecar put in the details of a workspace.
+//A URL the Cloud Controller could not send users to is logged and left out of the response
+func workspaceDashboardURL(log lager.Logger, workspace *models.ServiceManagerWorkspaceResponse) string {
+	if workspace == nil {
+		return ""
+	}
+
+	dashboard, ok := workspace.Details[dashboardURLDetail].(string)
+	if !ok || dashboard == "" {
+		return ""
+	}
+
+	dashboardURL, err := url.Parse(dashboard)
+	if err != nil || !dashboardURL.IsAbs() || (dashboardURL.Scheme != "http" && dashboardURL.Scheme != "https") || dashboardURL.Host == "" {
+		log.Info("invalid-dashboard-url", lager.Data{"dashboard-url": dashboard})
+		return ""
+	}
+
+	return dashboard
+}
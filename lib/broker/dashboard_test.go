@@ -0,0 +1,45 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceDashboardURL(t *testing.T) {
+	assert := assert.New(t)
+	log := lagertest.NewTestLogger("dashboard-test")
+
+	workspace := func(dashboard interface{}) *models.ServiceManagerWorkspaceResponse {
+		return &models.ServiceManagerWorkspaceResponse{Details: map[string]interface{}{dashboardURLDetail: dashboard}}
+	}
+
+	cases := []struct {
+		name      string
+		workspace *models.ServiceManagerWorkspaceResponse
+		dashboard string
+		logged    bool
+	}{
+		{"https", workspace("https://dashboard.example.com/instances/1?token=a"), "https://dashboard.example.com/instances/1?token=a", false},
+		{"http", workspace("http://dashboard.example.com:8080"), "http://dashboard.example.com:8080", false},
+		{"relative", workspace("/instances/1"), "", true},
+		{"without scheme", workspace("dashboard.example.com/instances/1"), "", true},
+		{"ftp", workspace("ftp://dashboard.example.com"), "", true},
+		{"javascript", workspace("javascript:alert(1)"), "", true},
+		{"without host", workspace("https:///instances/1"), "", true},
+		{"unparsable", workspace("https://dashboard.example.com/%zz"), "", true},
+		{"not a string", workspace(42), "", false},
+		{"empty", workspace(""), "", false},
+		{"missing", &models.ServiceManagerWorkspaceResponse{Details: map[string]interface{}{}}, "", false},
+		{"no details", &models.ServiceManagerWorkspaceResponse{}, "", false},
+		{"no workspace", nil, "", false},
+	}
+
+	for _, c := range cases {
+		logged := len(log.LogMessages())
+		assert.Equal(c.dashboard, workspaceDashboardURL(log, c.workspace), c.name)
+		assert.Equal(c.logged, len(log.LogMessages()) > logged, c.name)
+	}
+}
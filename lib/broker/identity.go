@@ -0,0 +1,100 @@
+package broker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/httpmiddleware"
+	"github.com/pivotal-golang/lager"
+)
+
+//originatingIdentity is the platform user on whose behalf the platform calls the broker
+type originatingIdentity struct {
+	//raw is the header as received, forwarded unchanged to the CSM
+	raw      string
+	platform string
+	value    map[string]interface{}
+}
+
+type originatingIdentityKey struct{}
+
+//parseOriginatingIdentity decodes a "platform base64(json)" originating identity header
+func parseOriginatingIdentity(header string) (*originatingIdentity, error) {
+	parts := strings.Fields(header)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid %s header, expected platform and value", csm.OriginatingIdentityHeader)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s header, value is not base64 encoded", csm.OriginatingIdentityHeader)
+	}
+
+	value := map[string]interface{}{}
+	err = json.Unmarshal(decoded, &value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s header, value is not a JSON object", csm.OriginatingIdentityHeader)
+	}
+
+	return &originatingIdentity{raw: strings.TrimSpace(header), platform: parts[0], value: value}, nil
+}
+
+//requestIdentity returns the originating identity recorded for the request or nil if the platform sent none
+func requestIdentity(r *http.Request) *originatingIdentity {
+	if r == nil {
+		return nil
+	}
+	identity, _ := r.Context().Value(originatingIdentityKey{}).(*originatingIdentity)
+	return identity
+}
+
+//requestLogger opens a logging session for a handler that records the request id and who originated the request
+func requestLogger(r *http.Request, task string) lager.Logger {
+	data := lager.Data{"request-id": httpmiddleware.RequestID(r)}
+	identity := requestIdentity(r)
+	if identity != nil {
+		data["originating-platform"] = identity.platform
+		data["originating-identity"] = identity.value
+	}
+	return brokerLogger.Session(task, data)
+}
+
+//withRequestContext makes the client send the request id and the originating identity of the request to the sidecar
+func withRequestContext(r *http.Request, client csm.CSM) csm.CSM {
+	if client == nil {
+		return client
+	}
+
+	client = client.WithRequestID(httpmiddleware.RequestID(r))
+	identity := requestIdentity(r)
+	if identity == nil {
+		return client
+	}
+	return client.WithOriginatingIdentity(identity.raw)
+}
+
+//originatingIdentityMiddleware decodes the originating identity header and records it in the request context,
+//malformed headers are rejected with 400
+func originatingIdentityMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(csm.OriginatingIdentityHeader)
+		if header == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		identity, err := parseOriginatingIdentity(header)
+		if err != nil {
+			brokerLogger.Info("originating-identity-rejected", lager.Data{"error": err.Error()})
+			writeBrokerError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), originatingIdentityKey{}, identity)))
+	})
+}
@@ -0,0 +1,100 @@
+package broker
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/broker/operations"
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/config/mocks"
+	"github.com/SUSE/cf-usb/lib/csm"
+	csmMocks "github.com/SUSE/cf-usb/lib/csm/mocks"
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/SUSE/cf-usb/lib/httpmiddleware"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseOriginatingIdentity(t *testing.T) {
+	assert := assert.New(t)
+
+	encode := func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	cases := []struct {
+		header   string
+		platform string
+		value    map[string]interface{}
+		rejected bool
+	}{
+		{"cloudfoundry " + encode(`{"user_id":"683ea748-3092-4ff4-b656-39cacc4d5360"}`), "cloudfoundry",
+			map[string]interface{}{"user_id": "683ea748-3092-4ff4-b656-39cacc4d5360"}, false},
+		{" kubernetes  " + encode(`{"username":"admin","uid":"1"}`) + " ", "kubernetes",
+			map[string]interface{}{"username": "admin", "uid": "1"}, false},
+		{"cloudfoundry " + encode(`{}`), "cloudfoundry", map[string]interface{}{}, false},
+		{"cloudfoundry", "", nil, true},
+		{"cloudfoundry " + encode(`{}`) + " extra", "", nil, true},
+		{"cloudfoundry not-base64!", "", nil, true},
+		{"cloudfoundry " + encode(`"user"`), "", nil, true},
+		{"cloudfoundry " + encode(`{"user_id":`), "", nil, true},
+	}
+
+	for _, c := range cases {
+		identity, err := parseOriginatingIdentity(c.header)
+		if c.rejected {
+			assert.Error(err, "header %q", c.header)
+			assert.Nil(identity, "header %q", c.header)
+			continue
+		}
+		assert.NoError(err, "header %q", c.header)
+		assert.Equal(c.platform, identity.platform)
+		assert.Equal(c.value, identity.value)
+		assert.Equal(strings.TrimSpace(c.header), identity.raw)
+	}
+}
+
+func TestGetServiceInstanceForwardsRequestContext(t *testing.T) {
+	assert := assert.New(t)
+	brokerLogger = lagertest.NewTestLogger("identity-test")
+	brokerOperations = newAsyncOperations(brokerLogger)
+
+	header := "cloudfoundry " + base64.StdEncoding.EncodeToString([]byte(`{"user_id":"user"}`))
+	instance := config.Instance{TargetURL: "http://csm", Service: brokermodel.CatalogService{ID: "service"}}
+
+	serviceID := "service"
+	for _, id := range []*string{&serviceID, nil} {
+		provider := new(mocks.Provider)
+		provider.On("LoadConfiguration").Return(&config.Config{Instances: map[string]config.Instance{"driver": instance}}, nil)
+		brokerConfigProvider = provider
+
+		client, scoped, forwarding := new(csmMocks.CSM), new(csmMocks.CSM), new(csmMocks.CSM)
+		client.On("WithRequestID", "request").Return(scoped)
+		scoped.On("WithOriginatingIdentity", header).Return(forwarding)
+		forwarding.On("GetWorkspace", "instance").Return(&models.ServiceManagerWorkspaceResponse{}, nil)
+
+		clients := new(csmMocks.ClientCache)
+		clients.On("GetClient", "driver", mock.Anything).Return(client, nil)
+		brokerCsmClients = clients
+
+		var responder middleware.Responder
+		handler := httpmiddleware.RequestIDs(originatingIdentityMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			responder = getServiceInstanceHandler(operations.GetServiceInstanceParams{HTTPRequest: r, InstanceID: "instance", ServiceID: id}, nil)
+		})))
+
+		request := httptest.NewRequest("GET", "/v2/service_instances/instance", nil)
+		request.Header.Set(csm.RequestIDHeader, "request")
+		request.Header.Set(csm.OriginatingIdentityHeader, header)
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		assert.IsType(&operations.GetServiceInstanceOK{}, responder)
+		forwarding.AssertCalled(t, "GetWorkspace", "instance")
+		client.AssertNotCalled(t, "GetWorkspace", "instance")
+	}
+}
@@ -51,6 +51,12 @@ type BrokerAPI struct {
 	// it performs authentication with basic auth
 	BasicAuth func(string, string) (interface{}, error)
 
+	// GetServiceBindingHandler sets the operation handler for the get service binding operation
+	GetServiceBindingHandler GetServiceBindingHandler
+	// GetServiceBindingLastOperationHandler sets the operation handler for the get service binding last operation operation
+	GetServiceBindingLastOperationHandler GetServiceBindingLastOperationHandler
+	// GetServiceInstanceHandler sets the operation handler for the get service instance operation
+	GetServiceInstanceHandler GetServiceInstanceHandler
 	// GetServiceInstancesInstanceIDLastOperationHandler sets the operation handler for the get service instances instance ID last operation operation
 	GetServiceInstancesInstanceIDLastOperationHandler GetServiceInstancesInstanceIDLastOperationHandler
 	// CatalogCatalogHandler sets the operation handler for the catalog operation
@@ -127,6 +133,18 @@ func (o *BrokerAPI) Validate() error {
 		unregistered = append(unregistered, "BasicAuth")
 	}
 
+	if o.GetServiceBindingHandler == nil {
+		unregistered = append(unregistered, "GetServiceBindingHandler")
+	}
+
+	if o.GetServiceBindingLastOperationHandler == nil {
+		unregistered = append(unregistered, "GetServiceBindingLastOperationHandler")
+	}
+
+	if o.GetServiceInstanceHandler == nil {
+		unregistered = append(unregistered, "GetServiceInstanceHandler")
+	}
+
 	if o.GetServiceInstancesInstanceIDLastOperationHandler == nil {
 		unregistered = append(unregistered, "GetServiceInstancesInstanceIDLastOperationHandler")
 	}
@@ -238,6 +256,21 @@ func (o *BrokerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/service_instances/{instance_id}/service_bindings/{binding_id}"] = NewGetServiceBinding(o.context, o.GetServiceBindingHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/service_instances/{instance_id}/service_bindings/{binding_id}/last_operation"] = NewGetServiceBindingLastOperation(o.context, o.GetServiceBindingLastOperationHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/service_instances/{instance_id}"] = NewGetServiceInstance(o.context, o.GetServiceInstanceHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
 	}
@@ -10,16 +10,16 @@ import (
 )
 
 // CatalogHandlerFunc turns a function with the right signature into a catalog handler
-type CatalogHandlerFunc func(interface{}) middleware.Responder
+type CatalogHandlerFunc func(CatalogParams, interface{}) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn CatalogHandlerFunc) Handle(principal interface{}) middleware.Responder {
-	return fn(principal)
+func (fn CatalogHandlerFunc) Handle(params CatalogParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
 }
 
 // CatalogHandler interface for that can handle valid catalog params
 type CatalogHandler interface {
-	Handle(interface{}) middleware.Responder
+	Handle(CatalogParams, interface{}) middleware.Responder
 }
 
 // NewCatalog creates a new http.Handler for the catalog operation
@@ -41,6 +41,8 @@ type Catalog struct {
 
 func (o *Catalog) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	route, _ := o.Context.RouteInfo(r)
+	var Params = NewCatalogParams()
+
 	uprinc, err := o.Context.Authorize(r, route)
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
@@ -51,12 +53,12 @@ func (o *Catalog) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		principal = uprinc
 	}
 
-	if err := o.Context.BindValidRequest(r, route, nil); err != nil { // bind params
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res := o.Handler.Handle(principal) // actually handle the request
+	res := o.Handler.Handle(Params, principal) // actually handle the request
 
 	o.Context.Respond(rw, r, route.Produces, route, res)
 
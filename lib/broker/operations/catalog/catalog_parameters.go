@@ -0,0 +1,67 @@
+package catalog
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/validate"
+
+	strfmt "github.com/go-openapi/strfmt"
+)
+
+// NewCatalogParams creates a new CatalogParams object
+// with the default values initialized.
+func NewCatalogParams() CatalogParams {
+	var ()
+	return CatalogParams{}
+}
+
+// CatalogParams contains all the bound params for the catalog operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters catalog
+type CatalogParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request
+
+	/*The version of the Service Broker API the platform speaks, as major.minor
+	  Required: true
+	  In: header
+	*/
+	XBrokerAPIVersion string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls
+func (o *CatalogParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+	o.HTTPRequest = r
+
+	if err := o.bindXBrokerAPIVersion(r.Header[http.CanonicalHeaderKey("X-Broker-API-Version")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (o *CatalogParams) bindXBrokerAPIVersion(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if err := validate.RequiredString("X-Broker-API-Version", "header", raw); err != nil {
+		return err
+	}
+
+	o.XBrokerAPIVersion = raw
+
+	return nil
+}
@@ -48,6 +48,43 @@ func (o *CatalogOK) WriteResponse(rw http.ResponseWriter, producer runtime.Produ
 	}
 }
 
+/*CatalogPreconditionFailed the X-Broker-API-Version is not supported by the broker
+
+swagger:response catalogPreconditionFailed
+*/
+type CatalogPreconditionFailed struct {
+
+	// In: body
+	Payload *brokermodel.BrokerError `json:"body,omitempty"`
+}
+
+// NewCatalogPreconditionFailed creates CatalogPreconditionFailed with default headers values
+func NewCatalogPreconditionFailed() *CatalogPreconditionFailed {
+	return &CatalogPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the catalog precondition failed response
+func (o *CatalogPreconditionFailed) WithPayload(payload *brokermodel.BrokerError) *CatalogPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the catalog precondition failed response
+func (o *CatalogPreconditionFailed) SetPayload(payload *brokermodel.BrokerError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CatalogPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 /*CatalogDefault generic error response
 
 swagger:response catalogDefault
@@ -10,6 +10,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
 
 	strfmt "github.com/go-openapi/strfmt"
 
@@ -32,6 +33,10 @@ type CreateServiceInstanceParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request
 
+	/*A value of true indicates that both the Cloud Controller and the requesting client support asynchronous operations. If this parameter is not included in the request, the broker performs the operation synchronously.
+	  In: query
+	*/
+	AcceptsIncomplete *bool
 	/*The instance_id of a service instance is provided by the Cloud Controller. This ID will be used for future requests (bind and deprovision), so the broker must use it to correlate the resource it creates.
 	  Required: true
 	  In: path
@@ -50,6 +55,13 @@ func (o *CreateServiceInstanceParams) BindRequest(r *http.Request, route *middle
 	var res []error
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qAcceptsIncomplete, qhkAcceptsIncomplete, _ := qs.GetOK("accepts_incomplete")
+	if err := o.bindAcceptsIncomplete(qAcceptsIncomplete, qhkAcceptsIncomplete, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rInstanceID, rhkInstanceID, _ := route.Params.GetOK("instance_id")
 	if err := o.bindInstanceID(rInstanceID, rhkInstanceID, route.Formats); err != nil {
 		res = append(res, err)
@@ -85,6 +97,24 @@ func (o *CreateServiceInstanceParams) BindRequest(r *http.Request, route *middle
 	return nil
 }
 
+func (o *CreateServiceInstanceParams) bindAcceptsIncomplete(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("accepts_incomplete", "query", "bool", raw)
+	}
+	o.AcceptsIncomplete = &value
+
+	return nil
+}
+
 func (o *CreateServiceInstanceParams) bindInstanceID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
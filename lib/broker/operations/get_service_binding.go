@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// GetServiceBindingHandlerFunc turns a function with the right signature into a get service binding handler
+type GetServiceBindingHandlerFunc func(GetServiceBindingParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetServiceBindingHandlerFunc) Handle(params GetServiceBindingParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// GetServiceBindingHandler interface for that can handle valid get service binding params
+type GetServiceBindingHandler interface {
+	Handle(GetServiceBindingParams, interface{}) middleware.Responder
+}
+
+// NewGetServiceBinding creates a new http.Handler for the get service binding operation
+func NewGetServiceBinding(ctx *middleware.Context, handler GetServiceBindingHandler) *GetServiceBinding {
+	return &GetServiceBinding{Context: ctx, Handler: handler}
+}
+
+/*GetServiceBinding swagger:route GET /service_instances/{instance_id}/service_bindings/{binding_id} getServiceBinding
+
+Fetching a Service Binding
+
+Returns the credentials of an existing binding, so that platforms can re-read them after the binding was created.
+
+*/
+type GetServiceBinding struct {
+	Context *middleware.Context
+	Handler GetServiceBindingHandler
+}
+
+func (o *GetServiceBinding) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewGetServiceBindingParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
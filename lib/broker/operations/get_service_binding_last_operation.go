@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// GetServiceBindingLastOperationHandlerFunc turns a function with the right signature into a get service binding last operation handler
+type GetServiceBindingLastOperationHandlerFunc func(GetServiceBindingLastOperationParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetServiceBindingLastOperationHandlerFunc) Handle(params GetServiceBindingLastOperationParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// GetServiceBindingLastOperationHandler interface for that can handle valid get service binding last operation params
+type GetServiceBindingLastOperationHandler interface {
+	Handle(GetServiceBindingLastOperationParams, interface{}) middleware.Responder
+}
+
+// NewGetServiceBindingLastOperation creates a new http.Handler for the get service binding last operation operation
+func NewGetServiceBindingLastOperation(ctx *middleware.Context, handler GetServiceBindingLastOperationHandler) *GetServiceBindingLastOperation {
+	return &GetServiceBindingLastOperation{Context: ctx, Handler: handler}
+}
+
+/*GetServiceBindingLastOperation swagger:route GET /service_instances/{instance_id}/service_bindings/{binding_id}/last_operation getServiceBindingLastOperation
+
+Polling Last Operation for Service Bindings (async only)
+
+When a broker returns status code 202 ACCEPTED for bind or unbind, the platform will begin to poll the /v2/service_instances/:instance_id/service_bindings/:binding_id/last_operation endpoint to obtain the state of the last requested operation. The broker response must contain the field state and an optional field description.
+
+*/
+type GetServiceBindingLastOperation struct {
+	Context *middleware.Context
+	Handler GetServiceBindingLastOperationHandler
+}
+
+func (o *GetServiceBindingLastOperation) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewGetServiceBindingLastOperationParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,140 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+)
+
+/*GetServiceBindingLastOperationOK Valid state values are 'in progress', 'succeeded', and 'failed'
+
+swagger:response getServiceBindingLastOperationOK
+*/
+type GetServiceBindingLastOperationOK struct {
+
+	// In: body
+	Payload *brokermodel.LastOperation `json:"body,omitempty"`
+}
+
+// NewGetServiceBindingLastOperationOK creates GetServiceBindingLastOperationOK with default headers values
+func NewGetServiceBindingLastOperationOK() *GetServiceBindingLastOperationOK {
+	return &GetServiceBindingLastOperationOK{}
+}
+
+// WithPayload adds the payload to the get service binding last operation o k response
+func (o *GetServiceBindingLastOperationOK) WithPayload(payload *brokermodel.LastOperation) *GetServiceBindingLastOperationOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service binding last operation o k response
+func (o *GetServiceBindingLastOperationOK) SetPayload(payload *brokermodel.LastOperation) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceBindingLastOperationOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*GetServiceBindingLastOperationGone Appropriate only for asynchronous unbind requests. The platform will consider this response a success and remove the binding from its database.
+
+swagger:response getServiceBindingLastOperationGone
+*/
+type GetServiceBindingLastOperationGone struct {
+
+	// In: body
+	Payload brokermodel.Empty `json:"body,omitempty"`
+}
+
+// NewGetServiceBindingLastOperationGone creates GetServiceBindingLastOperationGone with default headers values
+func NewGetServiceBindingLastOperationGone() *GetServiceBindingLastOperationGone {
+	return &GetServiceBindingLastOperationGone{}
+}
+
+// WithPayload adds the payload to the get service binding last operation gone response
+func (o *GetServiceBindingLastOperationGone) WithPayload(payload brokermodel.Empty) *GetServiceBindingLastOperationGone {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service binding last operation gone response
+func (o *GetServiceBindingLastOperationGone) SetPayload(payload brokermodel.Empty) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceBindingLastOperationGone) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(410)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
+/*GetServiceBindingLastOperationDefault generic error response
+
+swagger:response getServiceBindingLastOperationDefault
+*/
+type GetServiceBindingLastOperationDefault struct {
+	_statusCode int
+
+	// In: body
+	Payload *brokermodel.BrokerError `json:"body,omitempty"`
+}
+
+// NewGetServiceBindingLastOperationDefault creates GetServiceBindingLastOperationDefault with default headers values
+func NewGetServiceBindingLastOperationDefault(code int) *GetServiceBindingLastOperationDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetServiceBindingLastOperationDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get service binding last operation default response
+func (o *GetServiceBindingLastOperationDefault) WithStatusCode(code int) *GetServiceBindingLastOperationDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get service binding last operation default response
+func (o *GetServiceBindingLastOperationDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get service binding last operation default response
+func (o *GetServiceBindingLastOperationDefault) WithPayload(payload *brokermodel.BrokerError) *GetServiceBindingLastOperationDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service binding last operation default response
+func (o *GetServiceBindingLastOperationDefault) SetPayload(payload *brokermodel.BrokerError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceBindingLastOperationDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
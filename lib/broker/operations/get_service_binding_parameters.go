@@ -0,0 +1,134 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	strfmt "github.com/go-openapi/strfmt"
+)
+
+// NewGetServiceBindingParams creates a new GetServiceBindingParams object
+// with the default values initialized.
+func NewGetServiceBindingParams() GetServiceBindingParams {
+	var ()
+	return GetServiceBindingParams{}
+}
+
+// GetServiceBindingParams contains all the bound params for the get service binding operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters getServiceBinding
+type GetServiceBindingParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request
+
+	/*The binding_id of a service binding is provided by the Cloud Controller.
+	  Required: true
+	  In: path
+	*/
+	BindingID string
+	/*The instance_id of a service instance is provided by the Cloud Controller. This ID will be used for future requests (bind and deprovision), so the broker must use it to correlate the resource it creates.
+	  Required: true
+	  In: path
+	*/
+	InstanceID string
+	/*the plan id as stored in the catalog
+	  In: query
+	*/
+	PlanID *string
+	/*the service id as stored in the catalog
+	  In: query
+	*/
+	ServiceID *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls
+func (o *GetServiceBindingParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	rBindingID, rhkBindingID, _ := route.Params.GetOK("binding_id")
+	if err := o.bindBindingID(rBindingID, rhkBindingID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rInstanceID, rhkInstanceID, _ := route.Params.GetOK("instance_id")
+	if err := o.bindInstanceID(rInstanceID, rhkInstanceID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPlanID, qhkPlanID, _ := qs.GetOK("plan_id")
+	if err := o.bindPlanID(qPlanID, qhkPlanID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qServiceID, qhkServiceID, _ := qs.GetOK("service_id")
+	if err := o.bindServiceID(qServiceID, qhkServiceID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (o *GetServiceBindingParams) bindBindingID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	o.BindingID = raw
+
+	return nil
+}
+
+func (o *GetServiceBindingParams) bindInstanceID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	o.InstanceID = raw
+
+	return nil
+}
+
+func (o *GetServiceBindingParams) bindPlanID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.PlanID = &raw
+
+	return nil
+}
+
+func (o *GetServiceBindingParams) bindServiceID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ServiceID = &raw
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+)
+
+/*GetServiceBindingOK The service binding.
+
+swagger:response getServiceBindingOK
+*/
+type GetServiceBindingOK struct {
+
+	// In: body
+	Payload *brokermodel.BindingResponse `json:"body,omitempty"`
+}
+
+// NewGetServiceBindingOK creates GetServiceBindingOK with default headers values
+func NewGetServiceBindingOK() *GetServiceBindingOK {
+	return &GetServiceBindingOK{}
+}
+
+// WithPayload adds the payload to the get service binding o k response
+func (o *GetServiceBindingOK) WithPayload(payload *brokermodel.BindingResponse) *GetServiceBindingOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service binding o k response
+func (o *GetServiceBindingOK) SetPayload(payload *brokermodel.BindingResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceBindingOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*GetServiceBindingDefault generic error response
+
+swagger:response getServiceBindingDefault
+*/
+type GetServiceBindingDefault struct {
+	_statusCode int
+
+	// In: body
+	Payload *brokermodel.BrokerError `json:"body,omitempty"`
+}
+
+// NewGetServiceBindingDefault creates GetServiceBindingDefault with default headers values
+func NewGetServiceBindingDefault(code int) *GetServiceBindingDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetServiceBindingDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get service instances instance ID last operation default response
+func (o *GetServiceBindingDefault) WithStatusCode(code int) *GetServiceBindingDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get service instances instance ID last operation default response
+func (o *GetServiceBindingDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get service instances instance ID last operation default response
+func (o *GetServiceBindingDefault) WithPayload(payload *brokermodel.BrokerError) *GetServiceBindingDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service instances instance ID last operation default response
+func (o *GetServiceBindingDefault) SetPayload(payload *brokermodel.BrokerError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceBindingDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// GetServiceInstanceHandlerFunc turns a function with the right signature into a get service instance handler
+type GetServiceInstanceHandlerFunc func(GetServiceInstanceParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetServiceInstanceHandlerFunc) Handle(params GetServiceInstanceParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// GetServiceInstanceHandler interface for that can handle valid get service instance params
+type GetServiceInstanceHandler interface {
+	Handle(GetServiceInstanceParams, interface{}) middleware.Responder
+}
+
+// NewGetServiceInstance creates a new http.Handler for the get service instance operation
+func NewGetServiceInstance(ctx *middleware.Context, handler GetServiceInstanceHandler) *GetServiceInstance {
+	return &GetServiceInstance{Context: ctx, Handler: handler}
+}
+
+/*GetServiceInstance swagger:route GET /service_instances/{instance_id} getServiceInstance
+
+Fetching a Service Instance
+
+Returns the service instance as it is known to the service manager, so that platforms can re-read it after it was provisioned.
+
+*/
+type GetServiceInstance struct {
+	Context *middleware.Context
+	Handler GetServiceInstanceHandler
+}
+
+func (o *GetServiceInstance) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewGetServiceInstanceParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,104 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+)
+
+/*GetServiceInstanceOK The service instance.
+
+swagger:response getServiceInstanceOK
+*/
+type GetServiceInstanceOK struct {
+
+	// In: body
+	Payload *brokermodel.ServiceInstanceResource `json:"body,omitempty"`
+}
+
+// NewGetServiceInstanceOK creates GetServiceInstanceOK with default headers values
+func NewGetServiceInstanceOK() *GetServiceInstanceOK {
+	return &GetServiceInstanceOK{}
+}
+
+// WithPayload adds the payload to the get service instance o k response
+func (o *GetServiceInstanceOK) WithPayload(payload *brokermodel.ServiceInstanceResource) *GetServiceInstanceOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service instance o k response
+func (o *GetServiceInstanceOK) SetPayload(payload *brokermodel.ServiceInstanceResource) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceInstanceOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*GetServiceInstanceDefault generic error response
+
+swagger:response getServiceInstanceDefault
+*/
+type GetServiceInstanceDefault struct {
+	_statusCode int
+
+	// In: body
+	Payload *brokermodel.BrokerError `json:"body,omitempty"`
+}
+
+// NewGetServiceInstanceDefault creates GetServiceInstanceDefault with default headers values
+func NewGetServiceInstanceDefault(code int) *GetServiceInstanceDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetServiceInstanceDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get service instances instance ID last operation default response
+func (o *GetServiceInstanceDefault) WithStatusCode(code int) *GetServiceInstanceDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get service instances instance ID last operation default response
+func (o *GetServiceInstanceDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get service instances instance ID last operation default response
+func (o *GetServiceInstanceDefault) WithPayload(payload *brokermodel.BrokerError) *GetServiceInstanceDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get service instances instance ID last operation default response
+func (o *GetServiceInstanceDefault) SetPayload(payload *brokermodel.BrokerError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetServiceInstanceDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 
 	strfmt "github.com/go-openapi/strfmt"
@@ -33,6 +34,18 @@ type GetServiceInstancesInstanceIDLastOperationParams struct {
 	  In: path
 	*/
 	InstanceID string
+	/*The operation token returned by the broker in the 202 Accepted response of an asynchronous operation.
+	  In: query
+	*/
+	Operation *string
+	/*the plan id as stored in the catalog
+	  In: query
+	*/
+	PlanID *string
+	/*the service id as stored in the catalog
+	  In: query
+	*/
+	ServiceID *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -41,11 +54,28 @@ func (o *GetServiceInstancesInstanceIDLastOperationParams) BindRequest(r *http.R
 	var res []error
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	rInstanceID, rhkInstanceID, _ := route.Params.GetOK("instance_id")
 	if err := o.bindInstanceID(rInstanceID, rhkInstanceID, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qOperation, qhkOperation, _ := qs.GetOK("operation")
+	if err := o.bindOperation(qOperation, qhkOperation, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPlanID, qhkPlanID, _ := qs.GetOK("plan_id")
+	if err := o.bindPlanID(qPlanID, qhkPlanID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qServiceID, qhkServiceID, _ := qs.GetOK("service_id")
+	if err := o.bindServiceID(qServiceID, qhkServiceID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -62,3 +92,45 @@ func (o *GetServiceInstancesInstanceIDLastOperationParams) bindInstanceID(rawDat
 
 	return nil
 }
+
+func (o *GetServiceInstancesInstanceIDLastOperationParams) bindOperation(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Operation = &raw
+
+	return nil
+}
+
+func (o *GetServiceInstancesInstanceIDLastOperationParams) bindPlanID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.PlanID = &raw
+
+	return nil
+}
+
+func (o *GetServiceInstancesInstanceIDLastOperationParams) bindServiceID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.ServiceID = &raw
+
+	return nil
+}
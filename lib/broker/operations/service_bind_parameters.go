@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/SUSE/cf-usb/lib/brokermodel"
 )
@@ -32,6 +33,10 @@ type ServiceBindParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request
 
+	/*A value of true indicates that both the Cloud Controller and the requesting client support asynchronous operations. If this parameter is not included in the request, the broker performs the operation synchronously.
+	  In: query
+	*/
+	AcceptsIncomplete *bool
 	/*
 	  Required: true
 	  In: body
@@ -55,6 +60,13 @@ func (o *ServiceBindParams) BindRequest(r *http.Request, route *middleware.Match
 	var res []error
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qAcceptsIncomplete, qhkAcceptsIncomplete, _ := qs.GetOK("accepts_incomplete")
+	if err := o.bindAcceptsIncomplete(qAcceptsIncomplete, qhkAcceptsIncomplete, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body brokermodel.Binding
@@ -95,6 +107,24 @@ func (o *ServiceBindParams) BindRequest(r *http.Request, route *middleware.Match
 	return nil
 }
 
+func (o *ServiceBindParams) bindAcceptsIncomplete(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("accepts_incomplete", "query", "bool", raw)
+	}
+	o.AcceptsIncomplete = &value
+
+	return nil
+}
+
 func (o *ServiceBindParams) bindBindingID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
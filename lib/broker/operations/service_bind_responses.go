@@ -85,6 +85,42 @@ func (o *ServiceBindCreated) WriteResponse(rw http.ResponseWriter, producer runt
 	}
 }
 
+/*ServiceBindAccepted Binding is in progress. This triggers the platform to poll the Service Binding Last Operation Endpoint for operation status.
+
+swagger:response serviceBindAccepted
+*/
+type ServiceBindAccepted struct {
+
+	// In: body
+	Payload brokermodel.Empty `json:"body,omitempty"`
+}
+
+// NewServiceBindAccepted creates ServiceBindAccepted with default headers values
+func NewServiceBindAccepted() *ServiceBindAccepted {
+	return &ServiceBindAccepted{}
+}
+
+// WithPayload adds the payload to the service bind accepted response
+func (o *ServiceBindAccepted) WithPayload(payload brokermodel.Empty) *ServiceBindAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the service bind accepted response
+func (o *ServiceBindAccepted) SetPayload(payload brokermodel.Empty) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ServiceBindAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
 /*ServiceBindConflict Should be returned if the requested binding already exists. The expected response body is {}, though the description field can be used to return a user-facing error message, as described in Broker Errors.
 
 swagger:response serviceBindConflict
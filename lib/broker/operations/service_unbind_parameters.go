@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/validate"
 
 	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewServiceUnbindParams creates a new ServiceUnbindParams object
@@ -30,6 +31,10 @@ type ServiceUnbindParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request
 
+	/*A value of true indicates that both the Cloud Controller and the requesting client support asynchronous operations. If this parameter is not included in the request, the broker performs the operation synchronously.
+	  In: query
+	*/
+	AcceptsIncomplete *bool
 	/*The binding_id of a service binding is provided by the Cloud Controller.
 	  Required: true
 	  In: path
@@ -60,6 +65,11 @@ func (o *ServiceUnbindParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	qs := runtime.Values(r.URL.Query())
 
+	qAcceptsIncomplete, qhkAcceptsIncomplete, _ := qs.GetOK("accepts_incomplete")
+	if err := o.bindAcceptsIncomplete(qAcceptsIncomplete, qhkAcceptsIncomplete, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rBindingID, rhkBindingID, _ := route.Params.GetOK("binding_id")
 	if err := o.bindBindingID(rBindingID, rhkBindingID, route.Formats); err != nil {
 		res = append(res, err)
@@ -86,6 +96,24 @@ func (o *ServiceUnbindParams) BindRequest(r *http.Request, route *middleware.Mat
 	return nil
 }
 
+func (o *ServiceUnbindParams) bindAcceptsIncomplete(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("accepts_incomplete", "query", "bool", raw)
+	}
+	o.AcceptsIncomplete = &value
+
+	return nil
+}
+
 func (o *ServiceUnbindParams) bindBindingID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
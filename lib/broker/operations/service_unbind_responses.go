@@ -47,6 +47,42 @@ func (o *ServiceUnbindOK) WriteResponse(rw http.ResponseWriter, producer runtime
 
 }
 
+/*ServiceUnbindAccepted Unbinding is in progress. This triggers the platform to poll the Service Binding Last Operation Endpoint for operation status.
+
+swagger:response serviceUnbindAccepted
+*/
+type ServiceUnbindAccepted struct {
+
+	// In: body
+	Payload brokermodel.Empty `json:"body,omitempty"`
+}
+
+// NewServiceUnbindAccepted creates ServiceUnbindAccepted with default headers values
+func NewServiceUnbindAccepted() *ServiceUnbindAccepted {
+	return &ServiceUnbindAccepted{}
+}
+
+// WithPayload adds the payload to the service unbind accepted response
+func (o *ServiceUnbindAccepted) WithPayload(payload brokermodel.Empty) *ServiceUnbindAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the service unbind accepted response
+func (o *ServiceUnbindAccepted) SetPayload(payload brokermodel.Empty) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ServiceUnbindAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
 /*ServiceUnbindGone Should be returned if the binding does not exist. The expected response body is {}.
 
 swagger:response serviceUnbindGone
@@ -0,0 +1,103 @@
+package broker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/pivotal-golang/lager"
+)
+
+var (
+	//orphanMitigationAttempts is how many times the broker tries to remove a resource left behind by a failed call
+	orphanMitigationAttempts = 5
+	//orphanMitigationBackoff is the delay before the first retry, doubled after every failed attempt
+	orphanMitigationBackoff = 2 * time.Second
+)
+
+//mitigateOrphanWorkspace removes, in the background, a workspace a failed provision may have left on the sidecar
+func mitigateOrphanWorkspace(log lager.Logger, client csm.CSM, instanceID string, cause error) {
+	if !csm.IsAmbiguous(cause) {
+		return
+	}
+
+	data := lager.Data{"instance-id": instanceID, "cause": cause.Error()}
+	log.Info("orphan-workspace-mitigation-started", data)
+
+	brokerOperations.startCleanup(instanceID, func() {
+		err := retryOrphanMitigation(func() error {
+			exists, _, err := client.WorkspaceExists(instanceID)
+			if err != nil || !exists {
+				return err
+			}
+			return client.DeleteWorkspace(instanceID)
+		})
+		if err != nil {
+			log.Error("orphan-workspace-mitigation-failed", err, data)
+			return
+		}
+		unregisterServiceInstance(log, instanceID)
+		log.Info("orphan-workspace-mitigation-completed", data)
+	})
+}
+
+//mitigateOrphanConnection removes, in the background, a connection a failed bind may have left on the sidecar
+func mitigateOrphanConnection(log lager.Logger, client csm.CSM, instanceID string, bindingID string, cause error) {
+	if !csm.IsAmbiguous(cause) {
+		return
+	}
+
+	removeOrphanConnection(log, client, instanceID, bindingID, cause)
+}
+
+//removeOrphanConnection deletes, in the background, a connection the broker will not hand out to the platform
+func removeOrphanConnection(log lager.Logger, client csm.CSM, instanceID string, bindingID string, cause error) {
+	data := lager.Data{"instance-id": instanceID, "binding-id": bindingID, "cause": cause.Error()}
+	log.Info("orphan-connection-mitigation-started", data)
+
+	brokerOperations.startCleanup(bindingID, func() {
+		err := retryOrphanMitigation(func() error {
+			exists, _, err := client.ConnectionExists(instanceID, bindingID)
+			if err != nil || !exists {
+				return err
+			}
+			return client.DeleteConnection(instanceID, bindingID)
+		})
+		if err != nil {
+			log.Error("orphan-connection-mitigation-failed", err, data)
+			return
+		}
+		unregisterServiceBinding(log, bindingID)
+		log.Info("orphan-connection-mitigation-completed", data)
+	})
+}
+
+//retryOrphanMitigation runs cleanup until it succeeds or orphanMitigationAttempts attempts failed
+func retryOrphanMitigation(cleanup func() error) error {
+	backoff := orphanMitigationBackoff
+
+	var err error
+	for attempt := 1; attempt <= orphanMitigationAttempts; attempt++ {
+		err = attemptOrphanMitigation(cleanup)
+		if err == nil {
+			return nil
+		}
+		if attempt < orphanMitigationAttempts {
+			time.Sleep(backoff)
+			backoff = backoff * 2
+		}
+	}
+	return err
+}
+
+//attemptOrphanMitigation runs a single attempt of cleanup, a panic on a malformed answer of the sidecar counts as a
+//failed attempt
+func attemptOrphanMitigation(cleanup func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Unexpected failure: %v", r)
+		}
+	}()
+
+	return cleanup()
+}
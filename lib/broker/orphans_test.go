@@ -0,0 +1,112 @@
+package broker
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/config/mocks"
+	"github.com/SUSE/cf-usb/lib/csm"
+	csmMocks "github.com/SUSE/cf-usb/lib/csm/mocks"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func setOrphanMitigation(t *testing.T, attempts int, backoff time.Duration) {
+	previousAttempts, previousBackoff := orphanMitigationAttempts, orphanMitigationBackoff
+	orphanMitigationAttempts, orphanMitigationBackoff = attempts, backoff
+	t.Cleanup(func() {
+		orphanMitigationAttempts, orphanMitigationBackoff = previousAttempts, previousBackoff
+	})
+}
+
+func TestRetryOrphanMitigation(t *testing.T) {
+	assert := assert.New(t)
+	setOrphanMitigation(t, 3, 5*time.Millisecond)
+
+	failure := errors.New("sidecar unavailable")
+
+	cases := []struct {
+		name     string
+		outcomes []string
+		calls    int
+		failed   bool
+		backoff  time.Duration
+	}{
+		{"first attempt", []string{"ok"}, 1, false, 0},
+		{"second attempt", []string{"error", "ok"}, 2, false, 5 * time.Millisecond},
+		{"after a panic", []string{"panic", "ok"}, 2, false, 5 * time.Millisecond},
+		{"all attempts fail", []string{"error", "panic", "error"}, 3, true, 15 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		calls := 0
+		start := time.Now()
+		err := retryOrphanMitigation(func() error {
+			outcome := c.outcomes[calls]
+			calls++
+			switch outcome {
+			case "error":
+				return failure
+			case "panic":
+				var message *string
+				return errors.New(*message)
+			}
+			return nil
+		})
+
+		assert.Equal(c.calls, calls, c.name)
+		assert.Equal(c.failed, err != nil, c.name)
+		assert.True(time.Since(start) >= c.backoff, c.name)
+	}
+}
+
+func TestStartCleanup(t *testing.T) {
+	assert := assert.New(t)
+	operations := newAsyncOperations(lagertest.NewTestLogger("orphans-test"))
+
+	release := make(chan struct{})
+	operations.startCleanup("instance", func() {
+		<-release
+	})
+	operations.startCleanup("instance", func() {
+		var message *string
+		panic(*message)
+	})
+
+	assert.True(operations.cleaningUp("instance"))
+	assert.False(operations.cleaningUp("other-instance"))
+	assert.False(operations.startIfIdle("instance", provisionOperation, "service", func() error { return nil }))
+
+	close(release)
+	background.Wait()
+
+	assert.False(operations.cleaningUp("instance"))
+	assert.True(operations.startIfIdle("instance", provisionOperation, "service", func() error { return nil }))
+	background.Wait()
+}
+
+func TestMitigateOrphanWorkspace(t *testing.T) {
+	assert := assert.New(t)
+	setOrphanMitigation(t, 2, time.Millisecond)
+
+	log := lagertest.NewTestLogger("orphans-test")
+	brokerOperations = newAsyncOperations(log)
+	provider := new(mocks.Provider)
+	provider.On("DeleteServiceInstance", "ambiguous").Return(nil)
+	brokerConfigProvider = provider
+
+	client := new(csmMocks.CSM)
+	client.On("WorkspaceExists", "ambiguous").Return(true, false, nil)
+	client.On("DeleteWorkspace", "ambiguous").Return(nil)
+
+	mitigateOrphanWorkspace(log, client, "rejected", &csm.Error{StatusCode: http.StatusConflict, Message: "exists"})
+	mitigateOrphanWorkspace(log, client, "ambiguous", &csm.Error{StatusCode: http.StatusBadGateway, Message: "The CSM answered with 502 Bad Gateway"})
+	background.Wait()
+
+	client.AssertNotCalled(t, "WorkspaceExists", "rejected")
+	client.AssertCalled(t, "DeleteWorkspace", "ambiguous")
+	provider.AssertCalled(t, "DeleteServiceInstance", "ambiguous")
+	assert.False(brokerOperations.cleaningUp("ambiguous"))
+}
@@ -0,0 +1,131 @@
+package broker
+
+import (
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/pivotal-golang/lager"
+)
+
+//The registry records what the broker created. It is bookkeeping only: failures to update it are logged
+//and never fail the request, the CSM stays the source of truth for the resources themselves.
+
+//registerServiceInstance records a service instance being provisioned on the driver instance that serves its service
+func registerServiceInstance(log lager.Logger, instanceID string, service *brokermodel.Service, state string) {
+	_, driverInstanceID, err := brokerConfigProvider.GetService(service.ServiceID)
+	if err != nil {
+		log.Error("registry-set-instance-failed", err, lager.Data{"instance-id": instanceID})
+		return
+	}
+
+	now := time.Now().UTC()
+	record := config.ServiceInstance{
+		DriverInstanceID: driverInstanceID,
+		ServiceID:        service.ServiceID,
+		PlanID:           service.PlanID,
+		OrganizationGUID: service.OrganizationGUID,
+		SpaceGUID:        service.SpaceGUID,
+		Parameters:       service.Parameters,
+		State:            state,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err = brokerConfigProvider.SetServiceInstance(instanceID, record)
+	if err != nil {
+		log.Error("registry-set-instance-failed", err, lager.Data{"instance-id": instanceID})
+	}
+}
+
+//updateServiceInstanceRecord applies update to the registry record of a service instance
+func updateServiceInstanceRecord(log lager.Logger, instanceID string, update func(record *config.ServiceInstance)) {
+	record, err := brokerConfigProvider.GetServiceInstance(instanceID)
+	if err != nil {
+		log.Error("registry-get-instance-failed", err, lager.Data{"instance-id": instanceID})
+		return
+	}
+	if record == nil {
+		log.Info("registry-instance-not-found", lager.Data{"instance-id": instanceID})
+		return
+	}
+
+	update(record)
+	record.UpdatedAt = time.Now().UTC()
+
+	err = brokerConfigProvider.SetServiceInstance(instanceID, *record)
+	if err != nil {
+		log.Error("registry-set-instance-failed", err, lager.Data{"instance-id": instanceID})
+	}
+}
+
+func setServiceInstanceState(log lager.Logger, instanceID string, state string) {
+	updateServiceInstanceRecord(log, instanceID, func(record *config.ServiceInstance) {
+		record.State = state
+	})
+}
+
+//updateServiceInstancePlan records the plan and parameters a service instance was updated to
+func updateServiceInstancePlan(log lager.Logger, instanceID string, plan *brokermodel.ServicePlan) {
+	updateServiceInstanceRecord(log, instanceID, func(record *config.ServiceInstance) {
+		if plan.PlanID != "" {
+			record.PlanID = plan.PlanID
+		}
+		if plan.Parameters != nil {
+			record.Parameters = plan.Parameters
+		}
+		record.State = config.StateSucceeded
+	})
+}
+
+func unregisterServiceInstance(log lager.Logger, instanceID string) {
+	err := brokerConfigProvider.DeleteServiceInstance(instanceID)
+	if err != nil {
+		log.Error("registry-delete-instance-failed", err, lager.Data{"instance-id": instanceID})
+	}
+}
+
+//registerServiceBinding records a binding of a service instance
+func registerServiceBinding(log lager.Logger, instanceID string, bindingID string, binding *brokermodel.Binding, state string) {
+	now := time.Now().UTC()
+	record := config.ServiceBinding{
+		InstanceID: instanceID,
+		AppGUID:    binding.AppGUID,
+		Parameters: binding.Parameters,
+		State:      state,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	err := brokerConfigProvider.SetServiceBinding(bindingID, record)
+	if err != nil {
+		log.Error("registry-set-binding-failed", err, lager.Data{"instance-id": instanceID, "binding-id": bindingID})
+	}
+}
+
+func setServiceBindingState(log lager.Logger, bindingID string, state string) {
+	record, err := brokerConfigProvider.GetServiceBinding(bindingID)
+	if err != nil {
+		log.Error("registry-get-binding-failed", err, lager.Data{"binding-id": bindingID})
+		return
+	}
+	if record == nil {
+		log.Info("registry-binding-not-found", lager.Data{"binding-id": bindingID})
+		return
+	}
+
+	record.State = state
+	record.UpdatedAt = time.Now().UTC()
+
+	err = brokerConfigProvider.SetServiceBinding(bindingID, *record)
+	if err != nil {
+		log.Error("registry-set-binding-failed", err, lager.Data{"binding-id": bindingID})
+	}
+}
+
+func unregisterServiceBinding(log lager.Logger, bindingID string) {
+	err := brokerConfigProvider.DeleteServiceBinding(bindingID)
+	if err != nil {
+		log.Error("registry-delete-binding-failed", err, lager.Data{"binding-id": bindingID})
+	}
+}
@@ -0,0 +1,90 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+func instanceCreateSchema(plan brokermodel.Plan) *brokermodel.SchemaParameters {
+	if plan.Schemas == nil || plan.Schemas.ServiceInstance == nil {
+		return nil
+	}
+	return plan.Schemas.ServiceInstance.Create
+}
+
+func instanceUpdateSchema(plan brokermodel.Plan) *brokermodel.SchemaParameters {
+	if plan.Schemas == nil || plan.Schemas.ServiceInstance == nil {
+		return nil
+	}
+	return plan.Schemas.ServiceInstance.Update
+}
+
+func bindingCreateSchema(plan brokermodel.Plan) *brokermodel.SchemaParameters {
+	if plan.Schemas == nil || plan.Schemas.ServiceBinding == nil {
+		return nil
+	}
+	return plan.Schemas.ServiceBinding.Create
+}
+
+//validateParameters checks the parameters of a request against the JSON Schema a plan publishes for them.
+//It returns a readable description of the violations, or an empty string if the parameters are valid or there is no schema
+func validateParameters(schema *brokermodel.SchemaParameters, parameters map[string]interface{}) (violations string, err error) {
+	if schema == nil || schema.Parameters == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(schema.Parameters)
+	if err != nil {
+		return "", err
+	}
+	var parametersSchema spec.Schema
+	err = json.Unmarshal(data, &parametersSchema)
+	if err != nil {
+		return "", fmt.Errorf("Invalid parameters schema: %s", err.Error())
+	}
+
+	// The validator panics on schemas whose references can not be resolved
+	defer func() {
+		if r := recover(); r != nil {
+			violations = ""
+			err = fmt.Errorf("Invalid parameters schema: %v", r)
+		}
+	}()
+
+	if parameters == nil {
+		parameters = map[string]interface{}{}
+	}
+
+	result := validate.NewSchemaValidator(&parametersSchema, nil, "parameters", strfmt.Default).Validate(parameters)
+	if result.IsValid() {
+		return "", nil
+	}
+
+	messages := []string{}
+	for _, validationError := range result.Errors {
+		messages = append(messages, validationError.Error())
+	}
+	return "Invalid parameters: " + strings.Join(messages, "; "), nil
+}
+
+//currentPlanID is the plan a service instance is on when an update does not change it
+func currentPlanID(instanceID string, plan *brokermodel.ServicePlan) string {
+	if plan.PlanID != "" {
+		return plan.PlanID
+	}
+	if plan.PreviousValues != nil && plan.PreviousValues.PlanID != "" {
+		return plan.PreviousValues.PlanID
+	}
+
+	record, err := brokerConfigProvider.GetServiceInstance(instanceID)
+	if err != nil || record == nil {
+		return ""
+	}
+	return record.PlanID
+}
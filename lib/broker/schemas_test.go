@@ -0,0 +1,59 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateParameters(t *testing.T) {
+	assert := assert.New(t)
+
+	schema := &brokermodel.SchemaParameters{
+		Parameters: map[string]interface{}{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"type":    "object",
+			"properties": map[string]interface{}{
+				"size": map[string]interface{}{
+					"type": "integer",
+				},
+				"name": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			"required":             []string{"size"},
+			"additionalProperties": false,
+		},
+	}
+
+	cases := []struct {
+		schema     *brokermodel.SchemaParameters
+		parameters map[string]interface{}
+		violations bool
+		invalid    bool
+	}{
+		{nil, map[string]interface{}{"any": "thing"}, false, false},
+		{&brokermodel.SchemaParameters{}, map[string]interface{}{"any": "thing"}, false, false},
+		{schema, map[string]interface{}{"size": 10}, false, false},
+		{schema, map[string]interface{}{"size": 10, "name": "db"}, false, false},
+		{schema, nil, true, false},
+		{schema, map[string]interface{}{"size": "large"}, true, false},
+		{schema, map[string]interface{}{"size": 10, "other": true}, true, false},
+		{&brokermodel.SchemaParameters{Parameters: "object"}, nil, false, true},
+	}
+
+	for _, c := range cases {
+		violations, err := validateParameters(c.schema, c.parameters)
+		if c.invalid {
+			assert.Error(err, "parameters %v", c.parameters)
+			continue
+		}
+		assert.NoError(err, "parameters %v", c.parameters)
+		if c.violations {
+			assert.Contains(violations, "Invalid parameters: ", "parameters %v", c.parameters)
+		} else {
+			assert.Empty(violations, "parameters %v", c.parameters)
+		}
+	}
+}
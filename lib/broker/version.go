@@ -0,0 +1,140 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pivotal-golang/lager"
+)
+
+//brokerAPIVersionHeader is the header the platform uses to announce the Service Broker API version it speaks
+const brokerAPIVersionHeader string = "X-Broker-API-Version"
+
+//brokerAPIVersion is a major.minor Service Broker API version
+type brokerAPIVersion struct {
+	major int
+	minor int
+}
+
+var (
+	//supportedMajorVersion is the only major version of the Service Broker API the broker speaks
+	supportedMajorVersion = 2
+	//latestAPIVersion is the newest Service Broker API version the broker implements
+	latestAPIVersion = brokerAPIVersion{major: 2, minor: 14}
+	//asyncBindingsAPIVersion introduced asynchronous bindings and fetching instances and bindings
+	asyncBindingsAPIVersion = brokerAPIVersion{major: 2, minor: 14}
+)
+
+type apiVersionKey struct{}
+
+//parseAPIVersion parses a major.minor version string
+func parseAPIVersion(version string) (brokerAPIVersion, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) != 2 {
+		return brokerAPIVersion{}, fmt.Errorf("Invalid %s %q, expected major.minor", brokerAPIVersionHeader, version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return brokerAPIVersion{}, fmt.Errorf("Invalid %s %q, expected major.minor", brokerAPIVersionHeader, version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return brokerAPIVersion{}, fmt.Errorf("Invalid %s %q, expected major.minor", brokerAPIVersionHeader, version)
+	}
+
+	return brokerAPIVersion{major: major, minor: minor}, nil
+}
+
+//atLeast is true if v is the same as or newer than other
+func (v brokerAPIVersion) atLeast(other brokerAPIVersion) bool {
+	if v.major != other.major {
+		return v.major > other.major
+	}
+	return v.minor >= other.minor
+}
+
+func (v brokerAPIVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+//negotiateAPIVersion checks the requested version against the oldest version the deployment is configured for
+//and returns the version the broker will speak for the request
+func negotiateAPIVersion(requested string, minimum string) (brokerAPIVersion, error) {
+	if requested == "" {
+		return brokerAPIVersion{}, fmt.Errorf("Missing %s header", brokerAPIVersionHeader)
+	}
+
+	version, err := parseAPIVersion(requested)
+	if err != nil {
+		return brokerAPIVersion{}, err
+	}
+
+	if version.major != supportedMajorVersion {
+		return brokerAPIVersion{}, fmt.Errorf("Service Broker API version %s is not supported, the broker speaks %d.x", version, supportedMajorVersion)
+	}
+
+	if minimum != "" {
+		minimumVersion, err := parseAPIVersion(minimum)
+		if err != nil {
+			return brokerAPIVersion{}, fmt.Errorf("Invalid configured api_version %q", minimum)
+		}
+		if !version.atLeast(minimumVersion) {
+			return brokerAPIVersion{}, fmt.Errorf("Service Broker API version %s is not supported, the broker requires at least %s", version, minimumVersion)
+		}
+	}
+
+	if version.atLeast(latestAPIVersion) {
+		return latestAPIVersion, nil
+	}
+
+	return version, nil
+}
+
+//requestAPIVersion returns the version negotiated for the request by the version middleware
+func requestAPIVersion(r *http.Request) brokerAPIVersion {
+	if r != nil {
+		if version, ok := r.Context().Value(apiVersionKey{}).(brokerAPIVersion); ok {
+			return version
+		}
+	}
+	return latestAPIVersion
+}
+
+//supportsAsyncBindings is true if the platform can poll bindings and fetch instances and bindings
+func supportsAsyncBindings(r *http.Request) bool {
+	return requestAPIVersion(r).atLeast(asyncBindingsAPIVersion)
+}
+
+//apiVersionMiddleware rejects requests for Service Broker API versions the broker does not speak with 412
+//and records the negotiated version in the request context
+func apiVersionMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/swagger.json" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		requested := r.Header.Get(brokerAPIVersionHeader)
+		version, err := negotiateAPIVersion(requested, brokerMinAPIVersion)
+		if err != nil {
+			brokerLogger.Info("api-version-rejected", lager.Data{"requested": requested, "configured": brokerMinAPIVersion, "error": err.Error()})
+			writeBrokerError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+
+		brokerLogger.Debug("api-version-negotiated", lager.Data{"requested": requested, "negotiated": version.String()})
+
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, version)))
+	})
+}
+
+func writeBrokerError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(getBrokerError(message))
+}
@@ -0,0 +1,94 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		requested  string
+		minimum    string
+		negotiated string
+		rejected   bool
+	}{
+		{"2.14", "", "2.14", false},
+		{"2.10", "", "2.10", false},
+		{"2.15", "", "2.14", false},
+		{" 2.12 ", "2.10", "2.12", false},
+		{"2.10", "2.10", "2.10", false},
+		{"2.9", "2.10", "", true},
+		{"", "", "", true},
+		{"2", "", "", true},
+		{"2.x", "", "", true},
+		{"x.14", "", "", true},
+		{"3.0", "", "", true},
+		{"1.14", "", "", true},
+		{"2.14", "latest", "", true},
+	}
+
+	for _, c := range cases {
+		version, err := negotiateAPIVersion(c.requested, c.minimum)
+		if c.rejected {
+			assert.Error(err, "requested %q, minimum %q", c.requested, c.minimum)
+			continue
+		}
+		assert.NoError(err, "requested %q, minimum %q", c.requested, c.minimum)
+		assert.Equal(c.negotiated, version.String(), "requested %q, minimum %q", c.requested, c.minimum)
+	}
+}
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	brokerLogger = lagertest.NewTestLogger("broker-test")
+	brokerMinAPIVersion = "2.10"
+	defer func() { brokerMinAPIVersion = "" }()
+
+	var negotiated string
+	handler := apiVersionMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		negotiated = requestAPIVersion(r).String()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		path       string
+		requested  string
+		code       int
+		negotiated string
+	}{
+		{"/v2/catalog", "2.14", http.StatusOK, "2.14"},
+		{"/v2/catalog", "2.12", http.StatusOK, "2.12"},
+		{"/v2/catalog", "2.9", http.StatusPreconditionFailed, ""},
+		{"/v2/catalog", "3.0", http.StatusPreconditionFailed, ""},
+		{"/v2/catalog", "", http.StatusPreconditionFailed, ""},
+		{"/swagger.json", "", http.StatusOK, "2.14"},
+	}
+
+	for _, c := range cases {
+		negotiated = ""
+		request := httptest.NewRequest("GET", c.path, nil)
+		if c.requested != "" {
+			request.Header.Set(brokerAPIVersionHeader, c.requested)
+		}
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(c.code, recorder.Code, "path %s, requested %q", c.path, c.requested)
+		assert.Equal(c.negotiated, negotiated, "path %s, requested %q", c.path, c.requested)
+		if c.code == http.StatusPreconditionFailed {
+			brokerError := brokermodel.BrokerError{}
+			assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &brokerError))
+			assert.NotEmpty(*brokerError.Message)
+		}
+	}
+}
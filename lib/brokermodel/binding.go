@@ -24,9 +24,9 @@ type Binding struct {
 	 */
 	BindResource *BindResource `json:"bind_resource,omitempty"`
 
-	/* parameters
+	/* Configuration options for the service binding, as supplied by the user with cf bind-service -c. These are forwarded as is to the service manager.
 	 */
-	Parameters *Parameter `json:"parameters,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 
 	/* ID of the plan from the catalog. While not strictly necessary, some brokers might make use of this ID.
 	 */
@@ -79,11 +79,8 @@ func (m *Binding) validateParameters(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if m.Parameters != nil {
-
-		if err := m.Parameters.Validate(formats); err != nil {
-			return err
-		}
+	if swag.IsZero(m.Parameters) { // not required
+		return nil
 	}
 
 	return nil
@@ -5,6 +5,7 @@ package brokermodel
 
 import (
 	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/go-openapi/errors"
 )
@@ -26,14 +27,47 @@ type BindingResponse struct {
 	/* A URL to which Cloud Foundry should drain logs for the bound application. requires syslog_drain must be declared in the catalog endpoint or Cloud Foundry will consider the response invalid. For details, [see Application Log Streaming](https://docs.cloudfoundry.org/services/app-log-streaming.html).
 	 */
 	SyslogDrainURL string `json:"syslog_drain_url,omitempty"`
+
+	/* An array of configuration for mounting volumes. requires volume_mount must be declared in the catalog endpoint or Cloud Foundry will consider the response invalid.
+	 */
+	VolumeMounts []*VolumeMount `json:"volume_mounts,omitempty"`
 }
 
 // Validate validates this binding response
 func (m *BindingResponse) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateVolumeMounts(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+func (m *BindingResponse) validateVolumeMounts(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.VolumeMounts) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.VolumeMounts); i++ {
+
+		if swag.IsZero(m.VolumeMounts[i]) { // not required
+			continue
+		}
+
+		if m.VolumeMounts[i] != nil {
+
+			if err := m.VolumeMounts[i].Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
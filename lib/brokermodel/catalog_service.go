@@ -4,10 +4,14 @@ package brokermodel
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/validate"
 )
 
 /*CatalogService Schema of a service object see http://docs.cloudfoundry.org/services/api.html
@@ -20,6 +24,10 @@ type CatalogService struct {
 	 */
 	Bindable bool `json:"bindable,omitempty"`
 
+	/* Specifies whether the Fetching a Service Binding endpoint is supported for all plans.
+	 */
+	BindingsRetrievable bool `json:"bindings_retrievable,omitempty"`
+
 	/* dashboard client
 	 */
 	DashboardClient *DashboardClient `json:"dashboard_client,omitempty"`
@@ -32,6 +40,10 @@ type CatalogService struct {
 	 */
 	ID string `json:"id,omitempty"`
 
+	/* Specifies whether the Fetching a Service Instance endpoint is supported for all plans.
+	 */
+	InstancesRetrievable bool `json:"instances_retrievable,omitempty"`
+
 	/* metadata
 	 */
 	Metadata MetaData `json:"metadata,omitempty"`
@@ -127,12 +139,39 @@ func (m *CatalogService) validatePlans(formats strfmt.Registry) error {
 	return nil
 }
 
+var catalogServiceRequiresItemsEnum []interface{}
+
+func (m *CatalogService) validateRequiresItemsEnum(path, location string, value string) error {
+	if catalogServiceRequiresItemsEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["syslog_drain","route_forwarding","volume_mount"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			catalogServiceRequiresItemsEnum = append(catalogServiceRequiresItemsEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, catalogServiceRequiresItemsEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (m *CatalogService) validateRequires(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Requires) { // not required
 		return nil
 	}
 
+	for i := 0; i < len(m.Requires); i++ {
+
+		// value enum
+		if err := m.validateRequiresItemsEnum("requires"+"."+strconv.Itoa(i), "body", m.Requires[i]); err != nil {
+			return err
+		}
+
+	}
+
 	return nil
 }
 
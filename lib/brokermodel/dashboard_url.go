@@ -18,6 +18,10 @@ type DashboardURL struct {
 	/* The URL of a web-based management user interface for the service instance; we refer to this as a service dashboard. The URL should contain enough information for the dashboard to identify the resource being accessed (in the example below). For information on how users can authenticate with service dashboards via SSO, [see Dashboard Single Sign-On](https://docs.cloudfoundry.org/services/dashboard-sso.html).
 	 */
 	DashboardURL string `json:"dashboard_url,omitempty"`
+
+	/* For asynchronous responses, the operation token the Cloud Controller will send back when polling the last_operation endpoint.
+	 */
+	Operation string `json:"operation,omitempty"`
 }
 
 // Validate validates this dashboard Url
@@ -35,6 +35,10 @@ type Plan struct {
 	/* The CLI-friendly name of the plan that will appear in the catalog. All lowercase, no spaces.
 	 */
 	Name string `json:"name,omitempty"`
+
+	/* schemas
+	 */
+	Schemas *Schemas `json:"schemas,omitempty"`
 }
 
 // Validate validates this plan
@@ -46,6 +50,11 @@ func (m *Plan) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSchemas(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -68,6 +77,22 @@ func (m *Plan) validateMetadata(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Plan) validateSchemas(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Schemas) { // not required
+		return nil
+	}
+
+	if m.Schemas != nil {
+
+		if err := m.Schemas.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 /*PlanMetadata A list of metadata for a service plan. For more information, [see Service Metadata](https://docs.cloudfoundry.org/services/catalog-metadata.html).
 
 swagger:model PlanMetadata
@@ -85,6 +110,10 @@ type PlanMetadata struct {
 	/* A short name for the service plan to be displayed in a catalog.
 	 */
 	Name string `json:"name,omitempty"`
+
+	/* schemas
+	 */
+	Schemas *Schemas `json:"schemas,omitempty"`
 }
 
 // Validate validates this plan metadata
@@ -0,0 +1,31 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+)
+
+/*SchemaParameters Holds the JSON Schema the parameters of a request are validated against
+
+swagger:model SchemaParameters
+*/
+type SchemaParameters struct {
+
+	/* A JSON Schema (draft-04) describing the configuration parameters
+	 */
+	Parameters interface{} `json:"parameters,omitempty"`
+}
+
+// Validate validates this schema parameters
+func (m *SchemaParameters) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+)
+
+/*Schemas Schemas for the configuration parameters the plan accepts. The Cloud Controller publishes them so users can discover which parameters they may pass.
+
+swagger:model Schemas
+*/
+type Schemas struct {
+
+	/* service binding
+	 */
+	ServiceBinding *ServiceBindingSchema `json:"service_binding,omitempty"`
+
+	/* service instance
+	 */
+	ServiceInstance *ServiceInstanceSchema `json:"service_instance,omitempty"`
+}
+
+// Validate validates this schemas
+func (m *Schemas) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateServiceBinding(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateServiceInstance(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Schemas) validateServiceBinding(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ServiceBinding) { // not required
+		return nil
+	}
+
+	if m.ServiceBinding != nil {
+
+		if err := m.ServiceBinding.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Schemas) validateServiceInstance(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ServiceInstance) { // not required
+		return nil
+	}
+
+	if m.ServiceInstance != nil {
+
+		if err := m.ServiceInstance.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
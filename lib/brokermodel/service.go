@@ -24,9 +24,9 @@ type Service struct {
 	 */
 	OrganizationGUID string `json:"organization_guid,omitempty"`
 
-	/* parameteres
+	/* Configuration options for the service instance, as supplied by the user with cf create-service -c. These are forwarded as is to the service manager.
 	 */
-	Parameteres *Parameter `json:"parameteres,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 
 	/* The ID of the plan within the above service (from the catalog endpoint) that the user would like provisioned. Because plans have identifiers unique to a broker, this is enough information to determine what to provision.
 	 */
@@ -45,7 +45,7 @@ type Service struct {
 func (m *Service) Validate(formats strfmt.Registry) error {
 	var res []error
 
-	if err := m.validateParameteres(formats); err != nil {
+	if err := m.validateParameters(formats); err != nil {
 		// prop
 		res = append(res, err)
 	}
@@ -56,17 +56,14 @@ func (m *Service) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
-func (m *Service) validateParameteres(formats strfmt.Registry) error {
+func (m *Service) validateParameters(formats strfmt.Registry) error {
 
-	if swag.IsZero(m.Parameteres) { // not required
+	if swag.IsZero(m.Parameters) { // not required
 		return nil
 	}
 
-	if m.Parameteres != nil {
-
-		if err := m.Parameteres.Validate(formats); err != nil {
-			return err
-		}
+	if swag.IsZero(m.Parameters) { // not required
+		return nil
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+)
+
+/*ServiceBindingSchema Schemas for the parameters accepted when creating a service binding
+
+swagger:model ServiceBindingSchema
+*/
+type ServiceBindingSchema struct {
+
+	/* create
+	 */
+	Create *SchemaParameters `json:"create,omitempty"`
+}
+
+// Validate validates this service binding schema
+func (m *ServiceBindingSchema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCreate(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ServiceBindingSchema) validateCreate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Create) { // not required
+		return nil
+	}
+
+	if m.Create != nil {
+
+		if err := m.Create.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+)
+
+/*ServiceInstanceResource A service instance as returned by the fetch endpoint.
+
+swagger:model ServiceInstanceResource
+*/
+type ServiceInstanceResource struct {
+
+	/* The URL of a web-based management user interface for the service instance.
+	 */
+	DashboardURL string `json:"dashboard_url,omitempty"`
+
+	/* Configuration options for the service instance.
+	 */
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	/* The ID of the plan from the catalog.
+	 */
+	PlanID string `json:"plan_id,omitempty"`
+
+	/* The ID of the service from the catalog.
+	 */
+	ServiceID string `json:"service_id,omitempty"`
+}
+
+// Validate validates this service instance resource
+func (m *ServiceInstanceResource) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateParameters(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ServiceInstanceResource) validateParameters(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Parameters) { // not required
+		return nil
+	}
+
+	if swag.IsZero(m.Parameters) { // not required
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+)
+
+/*ServiceInstanceSchema Schemas for the parameters accepted when creating and updating a service instance
+
+swagger:model ServiceInstanceSchema
+*/
+type ServiceInstanceSchema struct {
+
+	/* create
+	 */
+	Create *SchemaParameters `json:"create,omitempty"`
+
+	/* update
+	 */
+	Update *SchemaParameters `json:"update,omitempty"`
+}
+
+// Validate validates this service instance schema
+func (m *ServiceInstanceSchema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCreate(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateUpdate(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ServiceInstanceSchema) validateCreate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Create) { // not required
+		return nil
+	}
+
+	if m.Create != nil {
+
+		if err := m.Create.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ServiceInstanceSchema) validateUpdate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Update) { // not required
+		return nil
+	}
+
+	if m.Update != nil {
+
+		if err := m.Update.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -20,9 +20,9 @@ type ServicePlan struct {
 	 */
 	AcceptsIncomplete bool `json:"accepts_incomplete,omitempty"`
 
-	/* parameters
+	/* Configuration options for the service instance, as supplied by the user with cf update-service -c. These are forwarded as is to the service manager.
 	 */
-	Parameters *Parameter `json:"parameters,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 
 	/* plan id
 	 */
@@ -63,11 +63,8 @@ func (m *ServicePlan) validateParameters(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if m.Parameters != nil {
-
-		if err := m.Parameters.Validate(formats); err != nil {
-			return err
-		}
+	if swag.IsZero(m.Parameters) { // not required
+		return nil
 	}
 
 	return nil
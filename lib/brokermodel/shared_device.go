@@ -0,0 +1,67 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/validate"
+)
+
+/*SharedDevice A device shared by the applications bound to the service instance
+
+swagger:model SharedDevice
+*/
+type SharedDevice struct {
+
+	/* Configuration object to be passed to the driver when the volume is mounted
+	 */
+	MountConfig map[string]interface{} `json:"mount_config,omitempty"`
+
+	/* ID of the shared volume to mount on every application instance
+
+	Required: true
+	*/
+	VolumeID *string `json:"volume_id"`
+}
+
+// Validate validates this shared device
+func (m *SharedDevice) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateMountConfig(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateVolumeID(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SharedDevice) validateMountConfig(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MountConfig) { // not required
+		return nil
+	}
+
+	return nil
+}
+
+func (m *SharedDevice) validateVolumeID(formats strfmt.Registry) error {
+
+	if err := validate.Required("volume_id", "body", m.VolumeID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package brokermodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/validate"
+)
+
+/*VolumeMount Describes a volume the bound application mounts
+
+swagger:model VolumeMount
+*/
+type VolumeMount struct {
+
+	/* The path in the application container onto which the volume will be mounted
+
+	Required: true
+	*/
+	ContainerDir *string `json:"container_dir"`
+
+	/* device
+
+	Required: true
+	*/
+	Device *SharedDevice `json:"device"`
+
+	/* A string specifying the type of device to mount. Currently the only supported value is "shared".
+
+	Required: true
+	*/
+	DeviceType *string `json:"device_type"`
+
+	/* Name of the volume driver plugin which manages the device
+
+	Required: true
+	*/
+	Driver *string `json:"driver"`
+
+	/* "r" to mount the volume read-only or "rw" to mount it read-write
+
+	Required: true
+	*/
+	Mode *string `json:"mode"`
+}
+
+// Validate validates this volume mount
+func (m *VolumeMount) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContainerDir(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateDevice(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateDeviceType(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateDriver(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateMode(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *VolumeMount) validateContainerDir(formats strfmt.Registry) error {
+
+	if err := validate.Required("container_dir", "body", m.ContainerDir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *VolumeMount) validateDevice(formats strfmt.Registry) error {
+
+	if err := validate.Required("device", "body", m.Device); err != nil {
+		return err
+	}
+
+	if m.Device != nil {
+
+		if err := m.Device.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var volumeMountTypeDeviceTypePropEnum []interface{}
+
+// prop value enum
+func (m *VolumeMount) validateDeviceTypeEnum(path, location string, value string) error {
+	if volumeMountTypeDeviceTypePropEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["shared"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			volumeMountTypeDeviceTypePropEnum = append(volumeMountTypeDeviceTypePropEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, volumeMountTypeDeviceTypePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *VolumeMount) validateDeviceType(formats strfmt.Registry) error {
+
+	if err := validate.Required("device_type", "body", m.DeviceType); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateDeviceTypeEnum("device_type", "body", *m.DeviceType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *VolumeMount) validateDriver(formats strfmt.Registry) error {
+
+	if err := validate.Required("driver", "body", m.Driver); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var volumeMountTypeModePropEnum []interface{}
+
+// prop value enum
+func (m *VolumeMount) validateModeEnum(path, location string, value string) error {
+	if volumeMountTypeModePropEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["r","rw"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			volumeMountTypeModePropEnum = append(volumeMountTypeModePropEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, volumeMountTypeModePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *VolumeMount) validateMode(formats strfmt.Registry) error {
+
+	if err := validate.Required("mode", "body", m.Mode); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateModeEnum("mode", "body", *m.Mode); err != nil {
+		return err
+	}
+
+	return nil
+}
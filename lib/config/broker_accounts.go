@@ -0,0 +1,55 @@
+package config
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//The broker accepts the accounts kept by the config provider, or the accounts of the configuration if none were kept.
+//The clear text credentials of the configuration, used to register the broker with the cloud controller, are
+//accepted as well as long as there is no account with the same username.
+
+//HashBrokerPassword returns the bcrypt hash of a broker password
+func HashBrokerPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+//AuthenticateBroker reports whether username and password are accepted by the broker
+func AuthenticateBroker(accounts []BrokerAccount, credentials BrokerCredentials, username string, password string) bool {
+	accountExists := false
+	authenticated := false
+
+	// Every account is checked so that the time taken does not tell which usernames exist
+	for _, account := range accounts {
+		if subtle.ConstantTimeCompare([]byte(account.Username), []byte(username)) != 1 {
+			continue
+		}
+		accountExists = true
+		if bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)) == nil {
+			authenticated = true
+		}
+	}
+
+	if authenticated || accountExists || credentials.Password == "" {
+		return authenticated
+	}
+
+	usernameMatches := subtle.ConstantTimeCompare([]byte(credentials.Username), []byte(username))
+	passwordMatches := subtle.ConstantTimeCompare([]byte(credentials.Password), []byte(password))
+	return usernameMatches&passwordMatches == 1
+}
+
+func parseBrokerAccounts(data []byte) ([]BrokerAccount, error) {
+	accounts := []BrokerAccount{}
+	err := json.Unmarshal(data, &accounts)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
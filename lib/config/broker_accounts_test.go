@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticateBroker(t *testing.T) {
+	assert := assert.New(t)
+
+	hash, err := HashBrokerPassword("secret")
+	assert.NoError(err)
+	assert.NotEqual("secret", hash)
+
+	accounts := []BrokerAccount{{Username: "platform", PasswordHash: hash}}
+	credentials := BrokerCredentials{Username: "cf", Password: "configured"}
+
+	assert.True(AuthenticateBroker(accounts, credentials, "platform", "secret"))
+	assert.False(AuthenticateBroker(accounts, credentials, "platform", "configured"))
+	assert.True(AuthenticateBroker(accounts, credentials, "cf", "configured"))
+	assert.False(AuthenticateBroker(accounts, credentials, "cf", "secret"))
+
+	// The configured password is no longer accepted once its username has an account
+	rotated := append(accounts, BrokerAccount{Username: "cf", PasswordHash: hash})
+	assert.False(AuthenticateBroker(rotated, credentials, "cf", "configured"))
+	assert.True(AuthenticateBroker(rotated, credentials, "cf", "secret"))
+
+	assert.False(AuthenticateBroker(nil, BrokerCredentials{Username: "cf"}, "cf", ""))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/SUSE/cf-usb/lib/brokermodel"
 )
@@ -12,11 +13,18 @@ type BrokerCredentials struct {
 	Password string
 }
 
+//BrokerAccount is a set of credentials a platform uses to connect to the broker, only a bcrypt hash of the password is kept
+type BrokerAccount struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+}
+
 //BrokerAPI provides the type for definition of broker API
 type BrokerAPI struct {
 	ExternalURL    string            `json:"external_url"`
 	Listen         string            `json:"listen"`
 	Credentials    BrokerCredentials `json:"credentials"`
+	Accounts       []BrokerAccount   `json:"accounts,omitempty"`
 	RequireTLS     bool              `json:"require_tls"`
 	ServerCertFile string            `json:"server_cert_file"`
 	ServerKeyFile  string            `json:"server_key_file"`
@@ -33,6 +41,11 @@ type ManagementAPI struct {
 	CloudController CloudController  `json:"cloud_controller"`
 }
 
+//MetricsAPI provides the type for definition of the listener serving the metrics
+type MetricsAPI struct {
+	Listen string `json:"listen"`
+}
+
 //Uaa provides the type to use for authentication and authorization
 type Uaa struct {
 	UaaAuth UaaAuth `json:"uaa"`
@@ -77,11 +90,49 @@ type RoutesRegister struct {
 
 //Config is the configuration definition
 type Config struct {
-	APIVersion     string              `json:"api_version"`
-	BrokerAPI      BrokerAPI           `json:"broker_api"`
-	ManagementAPI  *ManagementAPI      `json:"management_api,omitempty"`
-	Instances      map[string]Instance `json:"instances"`
-	RoutesRegister *RoutesRegister     `json:"routes_register"`
+	APIVersion      string              `json:"api_version"`
+	BrokerAPI       BrokerAPI           `json:"broker_api"`
+	ManagementAPI   *ManagementAPI      `json:"management_api,omitempty"`
+	Instances       map[string]Instance `json:"instances"`
+	RoutesRegister  *RoutesRegister     `json:"routes_register"`
+	MetricsAPI      *MetricsAPI         `json:"metrics_api,omitempty"`
+	ShutdownTimeout int                 `json:"shutdown_timeout,omitempty"`
+}
+
+//States of the service instances and bindings kept in the registry
+const (
+	StateInProgress string = "in progress"
+	StateSucceeded  string = "succeeded"
+	StateFailed     string = "failed"
+)
+
+//ServiceInstance is the registry record of a service instance provisioned through the broker
+type ServiceInstance struct {
+	DriverInstanceID string                 `json:"driver_instance_id"`
+	ServiceID        string                 `json:"service_id"`
+	PlanID           string                 `json:"plan_id"`
+	OrganizationGUID string                 `json:"organization_guid"`
+	SpaceGUID        string                 `json:"space_guid"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	State            string                 `json:"state"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+//ServiceBinding is the registry record of a binding created through the broker
+type ServiceBinding struct {
+	InstanceID string                 `json:"instance_id"`
+	AppGUID    string                 `json:"app_guid,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	State      string                 `json:"state"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+//Registry holds the service instances and bindings keyed by their ids
+type Registry struct {
+	ServiceInstances map[string]ServiceInstance `json:"service_instances"`
+	ServiceBindings  map[string]ServiceBinding  `json:"service_bindings"`
 }
 
 //Provider is the definition for a config provider
@@ -102,4 +153,16 @@ type Provider interface {
 	DeleteDial(dialid string) error
 	InstanceNameExists(driverInstanceName string) (bool, error)
 	GetPlan(plandid string) (plan *brokermodel.Plan, dialid string, instanceid string, err error)
+	SetServiceInstance(instanceid string, instance ServiceInstance) error
+	GetServiceInstance(instanceid string) (instance *ServiceInstance, err error)
+	GetServiceInstances() (instances map[string]ServiceInstance, err error)
+	DeleteServiceInstance(instanceid string) error
+	SetServiceBinding(bindingid string, binding ServiceBinding) error
+	GetServiceBinding(bindingid string) (binding *ServiceBinding, err error)
+	GetServiceBindings(instanceid string) (bindings map[string]ServiceBinding, err error)
+	DeleteServiceBinding(bindingid string) error
+	GetBrokerAccounts() (accounts []BrokerAccount, err error)
+	SetBrokerAccounts(accounts []BrokerAccount) error
+	Ping() error
+	Close() error
 }
@@ -6,16 +6,28 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 
 	"github.com/SUSE/cf-usb/lib/brokermodel"
 )
 
 type fileConfig struct {
+	sync.Mutex
 	path   string
 	loaded bool
 	config *Config
 }
 
+//registryPath is the file next to the read-only configuration in which the registry is kept
+func (c *fileConfig) registryPath() string {
+	return c.path + ".registry"
+}
+
+//accountsPath is the file next to the read-only configuration in which the broker accounts are kept
+func (c *fileConfig) accountsPath() string {
+	return c.path + ".accounts"
+}
+
 //NewFileConfig builds and returns a new file config Provider
 func NewFileConfig(path string) Provider {
 	return &fileConfig{path: path, loaded: false}
@@ -195,3 +207,111 @@ func parseJSON(jsonConf []byte) (*Config, error) {
 	}
 	return config, nil
 }
+
+func (c *fileConfig) loadRegistry() (*Registry, error) {
+	data, err := ioutil.ReadFile(c.registryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return newRegistry(), nil
+		}
+		return nil, err
+	}
+
+	return parseRegistry(data)
+}
+
+func (c *fileConfig) saveRegistry(registry *Registry) error {
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a crash never leaves a truncated registry behind
+	temporaryPath := c.registryPath() + ".tmp"
+	err = ioutil.WriteFile(temporaryPath, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryPath, c.registryPath())
+}
+
+func (c *fileConfig) SetServiceInstance(instanceID string, instance ServiceInstance) error {
+	return setServiceInstance(c, instanceID, instance)
+}
+
+func (c *fileConfig) GetServiceInstance(instanceID string) (*ServiceInstance, error) {
+	return getServiceInstance(c, instanceID)
+}
+
+func (c *fileConfig) GetServiceInstances() (map[string]ServiceInstance, error) {
+	return getServiceInstances(c)
+}
+
+func (c *fileConfig) DeleteServiceInstance(instanceID string) error {
+	return deleteServiceInstance(c, instanceID)
+}
+
+func (c *fileConfig) SetServiceBinding(bindingID string, binding ServiceBinding) error {
+	return setServiceBinding(c, bindingID, binding)
+}
+
+func (c *fileConfig) GetServiceBinding(bindingID string) (*ServiceBinding, error) {
+	return getServiceBinding(c, bindingID)
+}
+
+func (c *fileConfig) GetServiceBindings(instanceID string) (map[string]ServiceBinding, error) {
+	return getServiceBindings(c, instanceID)
+}
+
+func (c *fileConfig) DeleteServiceBinding(bindingID string) error {
+	return deleteServiceBinding(c, bindingID)
+}
+
+func (c *fileConfig) GetBrokerAccounts() ([]BrokerAccount, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	data, err := ioutil.ReadFile(c.accountsPath())
+	if err == nil {
+		return parseBrokerAccounts(data)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	config, err := c.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	return config.BrokerAPI.Accounts, nil
+}
+
+func (c *fileConfig) SetBrokerAccounts(accounts []BrokerAccount) error {
+	c.Lock()
+	defer c.Unlock()
+
+	data, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporaryPath := c.accountsPath() + ".tmp"
+	err = ioutil.WriteFile(temporaryPath, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryPath, c.accountsPath())
+}
+
+func (c *fileConfig) Ping() error {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (c *fileConfig) Close() error {
+	// Nothing to do here
+	return nil
+}
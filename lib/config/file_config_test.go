@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -105,6 +106,23 @@ func TestGetDriverDials(t *testing.T) {
 	assert.NotNil(dials[1].MAXDB)
 }
 
+func TestGetPlanSchemas(t *testing.T) {
+	assert := assert.New(t)
+
+	_, configuration, err := loadConfigAsset()
+	assert.NoError(err)
+
+	plan, _, _, err := configuration.GetPlan("888B59E0-C2A1-4AB6-9335-2E90114A8F07")
+	assert.NoError(err)
+	assert.NotNil(plan.Schemas)
+	assert.NotNil(plan.Schemas.ServiceInstance.Create.Parameters)
+	assert.Nil(plan.Schemas.ServiceBinding)
+
+	plan, _, _, err = configuration.GetPlan("53425178-F731-49E7-9E53-5CF4BE9D807A")
+	assert.NoError(err)
+	assert.Nil(plan.Schemas)
+}
+
 func TestGetUaaAuthConfig(t *testing.T) {
 	assert := assert.New(t)
 
@@ -137,3 +155,69 @@ func TestDriverInstanceNameExists(t *testing.T) {
 
 	assert.True(exist)
 }
+
+func TestFileRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "usb-registry")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	provider := NewFileConfig(filepath.Join(dir, "config.json"))
+
+	instances, err := provider.GetServiceInstances()
+	assert.NoError(err)
+	assert.Len(instances, 0)
+
+	err = provider.SetServiceInstance("instance", ServiceInstance{DriverInstanceID: "driver", PlanID: "plan", State: StateInProgress})
+	assert.NoError(err)
+	err = provider.SetServiceBinding("binding", ServiceBinding{InstanceID: "instance", State: StateSucceeded})
+	assert.NoError(err)
+
+	reloaded := NewFileConfig(filepath.Join(dir, "config.json"))
+	instance, err := reloaded.GetServiceInstance("instance")
+	assert.NoError(err)
+	assert.Equal("plan", instance.PlanID)
+
+	bindings, err := reloaded.GetServiceBindings("instance")
+	assert.NoError(err)
+	assert.Contains(bindings, "binding")
+
+	err = reloaded.DeleteServiceInstance("instance")
+	assert.NoError(err)
+
+	binding, err := reloaded.GetServiceBinding("binding")
+	assert.NoError(err)
+	assert.Nil(binding)
+}
+
+func TestFileBrokerAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "usb-accounts")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(configFile, []byte(`{"broker_api":{"accounts":[{"username":"platform","password_hash":"hash"}]}}`), 0600)
+	assert.NoError(err)
+
+	provider := NewFileConfig(configFile)
+
+	accounts, err := provider.GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Equal([]BrokerAccount{{Username: "platform", PasswordHash: "hash"}}, accounts)
+
+	err = provider.SetBrokerAccounts([]BrokerAccount{{Username: "cf", PasswordHash: "rotated"}})
+	assert.NoError(err)
+
+	accounts, err = NewFileConfig(configFile).GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Equal([]BrokerAccount{{Username: "cf", PasswordHash: "rotated"}}, accounts)
+	err = provider.SetBrokerAccounts([]BrokerAccount{})
+	assert.NoError(err)
+
+	accounts, err = NewFileConfig(configFile).GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Empty(accounts)
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/metrics"
+)
+
+//instrumentedProvider records the latency and errors of the calls made to provider
+type instrumentedProvider struct {
+	provider Provider
+}
+
+//NewInstrumentedProvider wraps provider so that the latency and errors of its calls are recorded in the metrics
+func NewInstrumentedProvider(provider Provider) Provider {
+	return &instrumentedProvider{provider: provider}
+}
+
+func (c *instrumentedProvider) InitializeConfiguration() error {
+	start := time.Now()
+	err := c.provider.InitializeConfiguration()
+	metrics.ObserveConfigProvider("initialize_configuration", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) LoadConfiguration() (*Config, error) {
+	start := time.Now()
+	result, err := c.provider.LoadConfiguration()
+	metrics.ObserveConfigProvider("load_configuration", time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedProvider) SaveConfiguration(config Config, overwrite bool) error {
+	start := time.Now()
+	err := c.provider.SaveConfiguration(config, overwrite)
+	metrics.ObserveConfigProvider("save_configuration", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) LoadDriverInstance(driverInstanceID string) (*Instance, error) {
+	start := time.Now()
+	result, err := c.provider.LoadDriverInstance(driverInstanceID)
+	metrics.ObserveConfigProvider("load_driver_instance", time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedProvider) GetUaaAuthConfig() (*UaaAuth, error) {
+	start := time.Now()
+	result, err := c.provider.GetUaaAuthConfig()
+	metrics.ObserveConfigProvider("get_uaa_auth_config", time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedProvider) SetInstance(instanceid string, driverInstance Instance) error {
+	start := time.Now()
+	err := c.provider.SetInstance(instanceid, driverInstance)
+	metrics.ObserveConfigProvider("set_instance", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetInstance(instanceid string) (*Instance, string, error) {
+	start := time.Now()
+	instance, driverID, err := c.provider.GetInstance(instanceid)
+	metrics.ObserveConfigProvider("get_instance", time.Since(start), err)
+	return instance, driverID, err
+}
+
+func (c *instrumentedProvider) DeleteInstance(instanceid string) error {
+	start := time.Now()
+	err := c.provider.DeleteInstance(instanceid)
+	metrics.ObserveConfigProvider("delete_instance", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) SetService(instanceid string, service brokermodel.CatalogService) error {
+	start := time.Now()
+	err := c.provider.SetService(instanceid, service)
+	metrics.ObserveConfigProvider("set_service", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetService(serviceid string) (*brokermodel.CatalogService, string, error) {
+	start := time.Now()
+	service, instanceid, err := c.provider.GetService(serviceid)
+	metrics.ObserveConfigProvider("get_service", time.Since(start), err)
+	return service, instanceid, err
+}
+
+func (c *instrumentedProvider) DeleteService(instanceid string) error {
+	start := time.Now()
+	err := c.provider.DeleteService(instanceid)
+	metrics.ObserveConfigProvider("delete_service", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) SetDial(instanceid string, dialid string, dial Dial) error {
+	start := time.Now()
+	err := c.provider.SetDial(instanceid, dialid, dial)
+	metrics.ObserveConfigProvider("set_dial", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetDial(dialid string) (*Dial, string, error) {
+	start := time.Now()
+	dial, instanceID, err := c.provider.GetDial(dialid)
+	metrics.ObserveConfigProvider("get_dial", time.Since(start), err)
+	return dial, instanceID, err
+}
+
+func (c *instrumentedProvider) DeleteDial(dialid string) error {
+	start := time.Now()
+	err := c.provider.DeleteDial(dialid)
+	metrics.ObserveConfigProvider("delete_dial", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) InstanceNameExists(driverInstanceName string) (bool, error) {
+	start := time.Now()
+	result, err := c.provider.InstanceNameExists(driverInstanceName)
+	metrics.ObserveConfigProvider("instance_name_exists", time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedProvider) GetPlan(plandid string) (*brokermodel.Plan, string, string, error) {
+	start := time.Now()
+	plan, dialid, instanceid, err := c.provider.GetPlan(plandid)
+	metrics.ObserveConfigProvider("get_plan", time.Since(start), err)
+	return plan, dialid, instanceid, err
+}
+
+func (c *instrumentedProvider) SetServiceInstance(instanceid string, instance ServiceInstance) error {
+	start := time.Now()
+	err := c.provider.SetServiceInstance(instanceid, instance)
+	metrics.ObserveConfigProvider("set_service_instance", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetServiceInstance(instanceid string) (*ServiceInstance, error) {
+	start := time.Now()
+	instance, err := c.provider.GetServiceInstance(instanceid)
+	metrics.ObserveConfigProvider("get_service_instance", time.Since(start), err)
+	return instance, err
+}
+
+func (c *instrumentedProvider) GetServiceInstances() (map[string]ServiceInstance, error) {
+	start := time.Now()
+	instances, err := c.provider.GetServiceInstances()
+	metrics.ObserveConfigProvider("get_service_instances", time.Since(start), err)
+	return instances, err
+}
+
+func (c *instrumentedProvider) DeleteServiceInstance(instanceid string) error {
+	start := time.Now()
+	err := c.provider.DeleteServiceInstance(instanceid)
+	metrics.ObserveConfigProvider("delete_service_instance", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) SetServiceBinding(bindingid string, binding ServiceBinding) error {
+	start := time.Now()
+	err := c.provider.SetServiceBinding(bindingid, binding)
+	metrics.ObserveConfigProvider("set_service_binding", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetServiceBinding(bindingid string) (*ServiceBinding, error) {
+	start := time.Now()
+	binding, err := c.provider.GetServiceBinding(bindingid)
+	metrics.ObserveConfigProvider("get_service_binding", time.Since(start), err)
+	return binding, err
+}
+
+func (c *instrumentedProvider) GetServiceBindings(instanceid string) (map[string]ServiceBinding, error) {
+	start := time.Now()
+	bindings, err := c.provider.GetServiceBindings(instanceid)
+	metrics.ObserveConfigProvider("get_service_bindings", time.Since(start), err)
+	return bindings, err
+}
+
+func (c *instrumentedProvider) DeleteServiceBinding(bindingid string) error {
+	start := time.Now()
+	err := c.provider.DeleteServiceBinding(bindingid)
+	metrics.ObserveConfigProvider("delete_service_binding", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) GetBrokerAccounts() ([]BrokerAccount, error) {
+	start := time.Now()
+	accounts, err := c.provider.GetBrokerAccounts()
+	metrics.ObserveConfigProvider("get_broker_accounts", time.Since(start), err)
+	return accounts, err
+}
+
+func (c *instrumentedProvider) SetBrokerAccounts(accounts []BrokerAccount) error {
+	start := time.Now()
+	err := c.provider.SetBrokerAccounts(accounts)
+	metrics.ObserveConfigProvider("set_broker_accounts", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) Ping() error {
+	start := time.Now()
+	err := c.provider.Ping()
+	metrics.ObserveConfigProvider("ping", time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedProvider) Close() error {
+	return c.provider.Close()
+}
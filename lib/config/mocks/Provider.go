@@ -319,3 +319,216 @@ func (_m *Provider) GetPlan(plandid string) (*brokermodel.Plan, string, string,
 
 	return r0, r1, r2, r3
 }
+
+// SetServiceInstance provides a mock function with given fields: instanceid
+func (_m *Provider) SetServiceInstance(instanceid string, instance config.ServiceInstance) error {
+	ret := _m.Called(instanceid, instance)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, config.ServiceInstance) error); ok {
+		r0 = rf(instanceid, instance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetServiceInstance provides a mock function with given fields: instanceid
+func (_m *Provider) GetServiceInstance(instanceid string) (*config.ServiceInstance, error) {
+	ret := _m.Called(instanceid)
+
+	var r0 *config.ServiceInstance
+	if rf, ok := ret.Get(0).(func(string) *config.ServiceInstance); ok {
+		r0 = rf(instanceid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*config.ServiceInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(instanceid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceInstances provides a mock function with given fields:
+func (_m *Provider) GetServiceInstances() (map[string]config.ServiceInstance, error) {
+	ret := _m.Called()
+
+	var r0 map[string]config.ServiceInstance
+	if rf, ok := ret.Get(0).(func() map[string]config.ServiceInstance); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]config.ServiceInstance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteServiceInstance provides a mock function with given fields: instanceid
+func (_m *Provider) DeleteServiceInstance(instanceid string) error {
+	ret := _m.Called(instanceid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(instanceid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetServiceBinding provides a mock function with given fields: bindingid
+func (_m *Provider) SetServiceBinding(bindingid string, binding config.ServiceBinding) error {
+	ret := _m.Called(bindingid, binding)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, config.ServiceBinding) error); ok {
+		r0 = rf(bindingid, binding)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetServiceBinding provides a mock function with given fields: bindingid
+func (_m *Provider) GetServiceBinding(bindingid string) (*config.ServiceBinding, error) {
+	ret := _m.Called(bindingid)
+
+	var r0 *config.ServiceBinding
+	if rf, ok := ret.Get(0).(func(string) *config.ServiceBinding); ok {
+		r0 = rf(bindingid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*config.ServiceBinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bindingid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceBindings provides a mock function with given fields: instanceid
+func (_m *Provider) GetServiceBindings(instanceid string) (map[string]config.ServiceBinding, error) {
+	ret := _m.Called(instanceid)
+
+	var r0 map[string]config.ServiceBinding
+	if rf, ok := ret.Get(0).(func(string) map[string]config.ServiceBinding); ok {
+		r0 = rf(instanceid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]config.ServiceBinding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(instanceid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteServiceBinding provides a mock function with given fields: bindingid
+func (_m *Provider) DeleteServiceBinding(bindingid string) error {
+	ret := _m.Called(bindingid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bindingid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBrokerAccounts provides a mock function with given fields:
+func (_m *Provider) GetBrokerAccounts() ([]config.BrokerAccount, error) {
+	ret := _m.Called()
+
+	var r0 []config.BrokerAccount
+	if rf, ok := ret.Get(0).(func() []config.BrokerAccount); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.BrokerAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetBrokerAccounts provides a mock function with given fields: accounts
+func (_m *Provider) SetBrokerAccounts(accounts []config.BrokerAccount) error {
+	ret := _m.Called(accounts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]config.BrokerAccount) error); ok {
+		r0 = rf(accounts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Ping provides a mock function with given fields:
+func (_m *Provider) Ping() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields:
+func (_m *Provider) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
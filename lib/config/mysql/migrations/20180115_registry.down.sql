@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS `ServiceBindings`;
+DROP TABLE IF EXISTS `ServiceInstances`;
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- Registry of the service instances and bindings created through the broker
+
+BEGIN;
+
+-- -----------------------------------------------------
+-- Table `ServiceInstances`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `ServiceInstances` (
+	  `Guid` VARCHAR(36) NOT NULL,
+	  `Instances_Guid` VARCHAR(36) NOT NULL,
+	  `Services_Guid` VARCHAR(36) NOT NULL,
+	  `Plans_Guid` VARCHAR(36) NOT NULL,
+	  `OrganizationGuid` VARCHAR(36) NULL,
+	  `SpaceGuid` VARCHAR(36) NULL,
+	  `Parameters` BLOB NULL,
+	  `State` VARCHAR(20) NOT NULL,
+	  `CreatedAt` DATETIME NOT NULL,
+	  `UpdatedAt` DATETIME NOT NULL,
+	  PRIMARY KEY (`Guid`),
+	  INDEX `idx_ServiceInstances_Instances_Guid` (`Instances_Guid` ASC))
+	ENGINE = InnoDB;
+
+
+-- -----------------------------------------------------
+-- Table `ServiceBindings`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `ServiceBindings` (
+	  `Guid` VARCHAR(36) NOT NULL,
+	  `ServiceInstances_Guid` VARCHAR(36) NOT NULL,
+	  `AppGuid` VARCHAR(36) NULL,
+	  `Parameters` BLOB NULL,
+	  `State` VARCHAR(20) NOT NULL,
+	  `CreatedAt` DATETIME NOT NULL,
+	  `UpdatedAt` DATETIME NOT NULL,
+	  PRIMARY KEY (`Guid`),
+	  INDEX `idx_ServiceBindings_ServiceInstances_Guid` (`ServiceInstances_Guid` ASC))
+	ENGINE = InnoDB;
+
+COMMIT;
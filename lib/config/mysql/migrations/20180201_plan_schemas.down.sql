@@ -0,0 +1 @@
+ALTER TABLE `Plans` DROP COLUMN `Schemas`;
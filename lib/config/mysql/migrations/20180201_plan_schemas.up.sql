@@ -0,0 +1,3 @@
+-- JSON Schemas of the parameters a plan accepts
+
+ALTER TABLE `Plans` ADD COLUMN `Schemas` BLOB NULL;
@@ -0,0 +1,14 @@
+-- Broker accounts, a username may have more than one password while its password is rotated
+
+BEGIN;
+
+-- -----------------------------------------------------
+-- Table `BrokerAccounts`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `BrokerAccounts` (
+	  `Username` VARCHAR(255) NOT NULL,
+	  `PasswordHash` VARCHAR(60) NOT NULL,
+	  PRIMARY KEY (`Username`, `PasswordHash`))
+	ENGINE = InnoDB;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Records that the broker accounts were set, so that setting no accounts is not undone by the accounts of the
+-- configuration
+
+BEGIN;
+
+-- -----------------------------------------------------
+-- Table `BrokerAccountsSet`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `BrokerAccountsSet` (
+	  `Id` TINYINT NOT NULL,
+	  PRIMARY KEY (`Id`))
+	ENGINE = InnoDB;
+
+INSERT IGNORE INTO `BrokerAccountsSet` SELECT 1 FROM `BrokerAccounts` LIMIT 1;
+
+COMMIT;
@@ -67,7 +67,7 @@ func (c *mysqlConfig) InitializeConfiguration() error {
 	}
 
 	// Reopen the connection with the name in the URL
-	c.db, err = sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s?multiStatements=true", c.username, c.password, c.address, c.dbName))
+	c.db, err = sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s?multiStatements=true&parseTime=true", c.username, c.password, c.address, c.dbName))
 	if err != nil {
 		return err
 	}
@@ -187,6 +187,10 @@ func (c *mysqlConfig) SaveConfiguration(config Config, overwrite bool) error {
 			}
 		}
 
+		if config.MetricsAPI != nil {
+			transaction.Exec("INSERT INTO Config VALUES(?,?,?)", "LISTEN", config.MetricsAPI.Listen, "METRICS_API")
+		}
+
 		transaction.Exec("INSERT INTO Config VALUES(?,?,?)", "AUTHENTICATION", string(*config.ManagementAPI.Authentication), "MANAGEMENT_API")
 
 		err = transaction.Commit()
@@ -251,7 +255,8 @@ func (c *mysqlConfig) LoadDriverInstance(driverInstanceID string) (*Instance, er
 		var plan brokermodel.Plan
 		var metadata brokermodel.PlanMetadata
 		var meta []byte
-		if err := planRow.Scan(&planGUID, &plan.Name, &plan.Description, &plan.Free, &meta); err != nil {
+		var schemas []byte
+		if err := planRow.Scan(&planGUID, &plan.Name, &plan.Description, &plan.Free, &meta, &schemas); err != nil {
 			return nil, err
 		}
 		err := json.Unmarshal(meta, &metadata)
@@ -259,6 +264,12 @@ func (c *mysqlConfig) LoadDriverInstance(driverInstanceID string) (*Instance, er
 			return nil, err
 		}
 		plan.Metadata = &metadata
+		if len(schemas) > 0 {
+			err = json.Unmarshal(schemas, &plan.Schemas)
+			if err != nil {
+				return nil, err
+			}
+		}
 		plan.ID = planGUID
 		dial.Plan = plan
 
@@ -314,7 +325,9 @@ func (c *mysqlConfig) GetUaaAuthConfig() (*UaaAuth, error) {
 }
 
 func (c *mysqlConfig) SetInstance(instanceID string, instance Instance) error {
-	_, err := c.db.Exec("INSERT INTO Instances VALUES(?, ?, ?,?,?,?)", instanceID, instance.Name, instance.TargetURL, instance.AuthenticationKey, instance.CaCert, instance.SkipSsl)
+	_, err := c.db.Exec(`INSERT INTO Instances VALUES(?, ?, ?,?,?,?)
+		ON DUPLICATE KEY UPDATE Name=VALUES(Name), TargetURL=VALUES(TargetURL), AuthKey=VALUES(AuthKey), CaCert=VALUES(CaCert), SkipSSL=VALUES(SkipSSL)`,
+		instanceID, instance.Name, instance.TargetURL, instance.AuthenticationKey, instance.CaCert, instance.SkipSsl)
 	if err != nil {
 		return err
 	}
@@ -343,6 +356,9 @@ func (c *mysqlConfig) GetInstance(instanceID string) (*Instance, string, error)
 	var instanceGUID string
 
 	err := instanceRow.Scan(&instanceGUID, &instance.Name, &instance.TargetURL, &instance.AuthenticationKey, &instance.CaCert, &instance.SkipSsl)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
 	if err != nil {
 		return nil, "", err
 	}
@@ -405,7 +421,10 @@ func (c *mysqlConfig) SetService(instanceID string, service brokermodel.CatalogS
 	if err != nil {
 		return err
 	}
-	_, err = c.db.Exec("INSERT INTO Services VALUES(?,?,?,?,?,?,?,?,?,?)", service.ID, service.Bindable, dashboard, service.Description, metadata, service.Name, service.PlanUpdateable, tags, instanceID, requires)
+	_, err = c.db.Exec(`INSERT INTO Services VALUES(?,?,?,?,?,?,?,?,?,?)
+		ON DUPLICATE KEY UPDATE Bindable=VALUES(Bindable), DashboardClient=VALUES(DashboardClient), Description=VALUES(Description),
+		Metadata=VALUES(Metadata), Name=VALUES(Name), PlanUpdateable=VALUES(PlanUpdateable), Tags=VALUES(Tags), Requires=VALUES(Requires)`,
+		service.ID, service.Bindable, dashboard, service.Description, metadata, service.Name, service.PlanUpdateable, tags, instanceID, requires)
 	if err != nil {
 		return err
 	}
@@ -423,12 +442,10 @@ func (c *mysqlConfig) GetService(serviceID string) (*brokermodel.CatalogService,
 	var instanceID string
 	err := serviceRow.Scan(&service.ID, &service.Bindable, &dash, &service.Description, &meta, &service.Name, &service.PlanUpdateable, &tags, &instanceID, &requires)
 
-	var dashboard brokermodel.DashboardClient
-	err = json.Unmarshal(dash, &dashboard)
+	err = json.Unmarshal(dash, &service.DashboardClient)
 	if err != nil {
 		return nil, "", err
 	}
-	service.DashboardClient = &dashboard
 
 	err = json.Unmarshal(meta, &service.Metadata)
 	if err != nil {
@@ -448,12 +465,7 @@ func (c *mysqlConfig) GetService(serviceID string) (*brokermodel.CatalogService,
 }
 
 func (c *mysqlConfig) DeleteService(instanceID string) error {
-	var serviceGUID string
-	if err := c.db.QueryRow("SELECT Services_Guid FROM Instances WHERE Guid=?", instanceID).Scan(&serviceGUID); err != nil {
-		return err
-	}
-
-	_, err := c.db.Exec("DELETE FROM Services WHERE Guid=?", serviceGUID)
+	_, err := c.db.Exec("DELETE FROM Services WHERE Instances_Guid=?", instanceID)
 	if err != nil {
 		return err
 	}
@@ -472,21 +484,33 @@ func (c *mysqlConfig) SetDial(instanceID string, dialID string, dial Dial) error
 		return err
 	}
 
-	transaction, err := c.db.Begin()
+	schemas, err := json.Marshal(dial.Plan.Schemas)
 	if err != nil {
 		return err
 	}
-	_, err = transaction.Exec("INSERT INTO Plans VALUES(?, ?, ?,?, ?)", dial.Plan.ID, dial.Plan.Name, dial.Plan.Description, dial.Plan.Free, meta)
 
-	_, err = transaction.Exec("INSERT INTO Dials VALUES(?, ?, ?,?)", dialID, configuration, dial.Plan.ID, instanceID)
+	transaction, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = transaction.Exec(`INSERT INTO Plans VALUES(?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE Name=VALUES(Name), Description=VALUES(Description), Free=VALUES(Free),
+		Metadata=VALUES(Metadata), Schemas=VALUES(Schemas)`,
+		dial.Plan.ID, dial.Plan.Name, dial.Plan.Description, dial.Plan.Free, meta, schemas)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
 
+	_, err = transaction.Exec(`INSERT INTO Dials VALUES(?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE Configuration=VALUES(Configuration), Plans_Guid=VALUES(Plans_Guid), Instances_Guid=VALUES(Instances_Guid)`,
+		dialID, configuration, dial.Plan.ID, instanceID)
 	if err != nil {
-		err = transaction.Rollback()
+		transaction.Rollback()
 		return err
 	}
-	transaction.Commit()
 
-	return nil
+	return transaction.Commit()
 }
 
 func (c *mysqlConfig) GetDial(dialID string) (*Dial, string, error) {
@@ -498,6 +522,12 @@ func (c *mysqlConfig) GetDial(dialID string) (*Dial, string, error) {
 	var planGUID string
 	var instanceGUID string
 	err := dialRow.Scan(&dialGUID, &conf, &planGUID, &instanceGUID)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
 
 	var config json.RawMessage
 
@@ -510,6 +540,9 @@ func (c *mysqlConfig) GetDial(dialID string) (*Dial, string, error) {
 	if err != nil {
 		return nil, "", err
 	}
+	if plan == nil {
+		return nil, "", fmt.Errorf("plan %s of dial %s not found", planGUID, dialID)
+	}
 	var result Dial
 	result.Configuration = &config
 	result.Plan = *plan
@@ -518,28 +551,31 @@ func (c *mysqlConfig) GetDial(dialID string) (*Dial, string, error) {
 
 func (c *mysqlConfig) DeleteDial(dialID string) error {
 
-	dial, instanceID, err := c.GetDial(dialID)
+	dial, _, err := c.GetDial(dialID)
 	if err != nil {
 		return err
 	}
+	if dial == nil {
+		return nil
+	}
 
 	transaction, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
-	_, err = transaction.Exec("DELETE FROM Plans WHERE Guid=?", dial.Plan.ID)
-
-	_, err = transaction.Exec("DELETE FROM Dials WHERE Instances_Guid=?", instanceID)
+	_, err = transaction.Exec("DELETE FROM Dials WHERE Guid=?", dialID)
 	if err != nil {
-		err = transaction.Rollback()
+		transaction.Rollback()
 		return err
 	}
-	err = transaction.Commit()
+
+	_, err = transaction.Exec("DELETE FROM Plans WHERE Guid=?", dial.Plan.ID)
 	if err != nil {
+		transaction.Rollback()
 		return err
 	}
 
-	return nil
+	return transaction.Commit()
 }
 
 func (c *mysqlConfig) InstanceNameExists(driverInstanceName string) (bool, error) {
@@ -559,9 +595,13 @@ func (c *mysqlConfig) GetPlan(planid string) (*brokermodel.Plan, string, string,
 
 	var plan brokermodel.Plan
 	var meta []byte
+	var schemas []byte
 	var planGUID string
 
-	err := planRow.Scan(&planGUID, &plan.Name, &plan.Description, &plan.Free, &meta)
+	err := planRow.Scan(&planGUID, &plan.Name, &plan.Description, &plan.Free, &meta, &schemas)
+	if err == sql.ErrNoRows {
+		return nil, "", "", nil
+	}
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -571,6 +611,12 @@ func (c *mysqlConfig) GetPlan(planid string) (*brokermodel.Plan, string, string,
 		return nil, "", "", err
 	}
 	plan.Metadata = &metadata
+	if len(schemas) > 0 {
+		err = json.Unmarshal(schemas, &plan.Schemas)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
 	plan.ID = planGUID
 
 	var dialID string
@@ -582,3 +628,236 @@ func (c *mysqlConfig) GetPlan(planid string) (*brokermodel.Plan, string, string,
 	}
 	return &plan, dialID, instanceID, nil
 }
+
+func (c *mysqlConfig) SetServiceInstance(instanceID string, instance ServiceInstance) error {
+	parameters, err := json.Marshal(instance.Parameters)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.Exec(`INSERT INTO ServiceInstances VALUES(?,?,?,?,?,?,?,?,?,?)
+		ON DUPLICATE KEY UPDATE Instances_Guid=VALUES(Instances_Guid), Services_Guid=VALUES(Services_Guid), Plans_Guid=VALUES(Plans_Guid),
+		OrganizationGuid=VALUES(OrganizationGuid), SpaceGuid=VALUES(SpaceGuid), Parameters=VALUES(Parameters), State=VALUES(State), UpdatedAt=VALUES(UpdatedAt)`,
+		instanceID, instance.DriverInstanceID, instance.ServiceID, instance.PlanID, instance.OrganizationGUID, instance.SpaceGUID,
+		parameters, instance.State, instance.CreatedAt.UTC(), instance.UpdatedAt.UTC())
+	return err
+}
+
+func scanServiceInstance(row interface {
+	Scan(dest ...interface{}) error
+}) (string, *ServiceInstance, error) {
+	var instanceID string
+	var instance ServiceInstance
+	var organizationGUID sql.NullString
+	var spaceGUID sql.NullString
+	var parameters []byte
+
+	err := row.Scan(&instanceID, &instance.DriverInstanceID, &instance.ServiceID, &instance.PlanID, &organizationGUID, &spaceGUID,
+		&parameters, &instance.State, &instance.CreatedAt, &instance.UpdatedAt)
+	if err != nil {
+		return "", nil, err
+	}
+	instance.OrganizationGUID = organizationGUID.String
+	instance.SpaceGUID = spaceGUID.String
+
+	err = json.Unmarshal(parameters, &instance.Parameters)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return instanceID, &instance, nil
+}
+
+func (c *mysqlConfig) GetServiceInstance(instanceID string) (*ServiceInstance, error) {
+	_, instance, err := scanServiceInstance(c.db.QueryRow("SELECT * FROM ServiceInstances WHERE Guid=?", instanceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (c *mysqlConfig) GetServiceInstances() (map[string]ServiceInstance, error) {
+	rows, err := c.db.Query("SELECT * FROM ServiceInstances")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := make(map[string]ServiceInstance)
+	for rows.Next() {
+		instanceID, instance, err := scanServiceInstance(rows)
+		if err != nil {
+			return nil, err
+		}
+		instances[instanceID] = *instance
+	}
+
+	return instances, rows.Err()
+}
+
+func (c *mysqlConfig) DeleteServiceInstance(instanceID string) error {
+	transaction, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = transaction.Exec("DELETE FROM ServiceBindings WHERE ServiceInstances_Guid=?", instanceID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	_, err = transaction.Exec("DELETE FROM ServiceInstances WHERE Guid=?", instanceID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (c *mysqlConfig) SetServiceBinding(bindingID string, binding ServiceBinding) error {
+	parameters, err := json.Marshal(binding.Parameters)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.Exec(`INSERT INTO ServiceBindings VALUES(?,?,?,?,?,?,?)
+		ON DUPLICATE KEY UPDATE ServiceInstances_Guid=VALUES(ServiceInstances_Guid), AppGuid=VALUES(AppGuid),
+		Parameters=VALUES(Parameters), State=VALUES(State), UpdatedAt=VALUES(UpdatedAt)`,
+		bindingID, binding.InstanceID, binding.AppGUID, parameters, binding.State, binding.CreatedAt.UTC(), binding.UpdatedAt.UTC())
+	return err
+}
+
+func scanServiceBinding(row interface {
+	Scan(dest ...interface{}) error
+}) (string, *ServiceBinding, error) {
+	var bindingID string
+	var binding ServiceBinding
+	var appGUID sql.NullString
+	var parameters []byte
+
+	err := row.Scan(&bindingID, &binding.InstanceID, &appGUID, &parameters, &binding.State, &binding.CreatedAt, &binding.UpdatedAt)
+	if err != nil {
+		return "", nil, err
+	}
+	binding.AppGUID = appGUID.String
+
+	err = json.Unmarshal(parameters, &binding.Parameters)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return bindingID, &binding, nil
+}
+
+func (c *mysqlConfig) GetServiceBinding(bindingID string) (*ServiceBinding, error) {
+	_, binding, err := scanServiceBinding(c.db.QueryRow("SELECT * FROM ServiceBindings WHERE Guid=?", bindingID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+func (c *mysqlConfig) GetServiceBindings(instanceID string) (map[string]ServiceBinding, error) {
+	rows, err := c.db.Query("SELECT * FROM ServiceBindings WHERE ServiceInstances_Guid=?", instanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bindings := make(map[string]ServiceBinding)
+	for rows.Next() {
+		bindingID, binding, err := scanServiceBinding(rows)
+		if err != nil {
+			return nil, err
+		}
+		bindings[bindingID] = *binding
+	}
+
+	return bindings, rows.Err()
+}
+
+func (c *mysqlConfig) DeleteServiceBinding(bindingID string) error {
+	_, err := c.db.Exec("DELETE FROM ServiceBindings WHERE Guid=?", bindingID)
+	return err
+}
+
+func (c *mysqlConfig) GetBrokerAccounts() ([]BrokerAccount, error) {
+	rows, err := c.db.Query("SELECT Username, PasswordHash FROM BrokerAccounts")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []BrokerAccount{}
+	for rows.Next() {
+		var account BrokerAccount
+		err := rows.Scan(&account.Username, &account.PasswordHash)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var set int
+	err = c.db.QueryRow("SELECT COUNT(*) FROM BrokerAccountsSet").Scan(&set)
+	if err != nil {
+		return nil, err
+	}
+	if set > 0 {
+		return accounts, nil
+	}
+
+	config, err := c.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	return config.BrokerAPI.Accounts, nil
+}
+
+func (c *mysqlConfig) SetBrokerAccounts(accounts []BrokerAccount) error {
+	transaction, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = transaction.Exec("DELETE FROM BrokerAccounts")
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	_, err = transaction.Exec("INSERT IGNORE INTO BrokerAccountsSet VALUES(1)")
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	for _, account := range accounts {
+		_, err = transaction.Exec("INSERT INTO BrokerAccounts VALUES(?,?)", account.Username, account.PasswordHash)
+		if err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func (c *mysqlConfig) Ping() error {
+	return c.db.Ping()
+}
+
+func (c *mysqlConfig) Close() error {
+	return c.db.Close()
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +29,7 @@ func initMysql() (bool, error) {
 		return true, nil
 	}
 
-	provider, err := NewMysqlConfig(MysqlIntegrationConfig.address, MysqlIntegrationConfig.username, MysqlIntegrationConfig.password, MysqlIntegrationConfig.db, "")
+	provider, err := NewMysqlConfig(MysqlIntegrationConfig.address, MysqlIntegrationConfig.username, MysqlIntegrationConfig.password, MysqlIntegrationConfig.db, "", lagertest.NewTestLogger("mysql-config-test"))
 	if err != nil {
 		return true, err
 	}
@@ -137,3 +139,80 @@ func Test_MysqlInstanceTest(t *testing.T) {
 	err = MysqlIntegrationConfig.Provider.DeleteInstance("testInstanceGuid")
 	assert.NoError(err)
 }
+
+func Test_MysqlRegistry(t *testing.T) {
+	assert := assert.New(t)
+	skip, err := initMysql()
+	if err != nil {
+		t.Error(err)
+	}
+	if skip {
+		t.Skip("MYSQL test environment variables not set")
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	err = MysqlIntegrationConfig.Provider.SetServiceInstance("I0000000-0000-0000-0000-000000000001", ServiceInstance{
+		DriverInstanceID: "A0000000-0000-0000-0000-000000000002",
+		ServiceID:        "83E94C97-C755-46A5-8653-461517EB442A",
+		PlanID:           "53425178-F731-49E7-9E53-5CF4BE9D807A",
+		Parameters:       map[string]interface{}{"size": "small"},
+		State:            StateSucceeded,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+	assert.NoError(err)
+
+	err = MysqlIntegrationConfig.Provider.SetServiceBinding("C0000000-0000-0000-0000-000000000001", ServiceBinding{
+		InstanceID: "I0000000-0000-0000-0000-000000000001",
+		AppGUID:    "app",
+		State:      StateSucceeded,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	assert.NoError(err)
+
+	instance, err := MysqlIntegrationConfig.Provider.GetServiceInstance("I0000000-0000-0000-0000-000000000001")
+	assert.NoError(err)
+	assert.Equal("small", instance.Parameters["size"])
+	assert.True(now.Equal(instance.CreatedAt))
+
+	bindings, err := MysqlIntegrationConfig.Provider.GetServiceBindings("I0000000-0000-0000-0000-000000000001")
+	assert.NoError(err)
+	assert.Len(bindings, 1)
+
+	err = MysqlIntegrationConfig.Provider.DeleteServiceInstance("I0000000-0000-0000-0000-000000000001")
+	assert.NoError(err)
+
+	instance, err = MysqlIntegrationConfig.Provider.GetServiceInstance("I0000000-0000-0000-0000-000000000001")
+	assert.NoError(err)
+	assert.Nil(instance)
+
+	binding, err := MysqlIntegrationConfig.Provider.GetServiceBinding("C0000000-0000-0000-0000-000000000001")
+	assert.NoError(err)
+	assert.Nil(binding)
+}
+
+func Test_MysqlSetEmptyBrokerAccounts(t *testing.T) {
+	assert := assert.New(t)
+	skip, err := initMysql()
+	if err != nil {
+		t.Error(err)
+	}
+	if skip {
+		t.Skip("MYSQL test environment variables not set")
+	}
+
+	err = MysqlIntegrationConfig.Provider.SetBrokerAccounts([]BrokerAccount{{Username: "cf", PasswordHash: "hash"}})
+	assert.NoError(err)
+
+	accounts, err := MysqlIntegrationConfig.Provider.GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Equal([]BrokerAccount{{Username: "cf", PasswordHash: "hash"}}, accounts)
+
+	err = MysqlIntegrationConfig.Provider.SetBrokerAccounts([]BrokerAccount{})
+	assert.NoError(err)
+
+	accounts, err = MysqlIntegrationConfig.Provider.GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Empty(accounts)
+}
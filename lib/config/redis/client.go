@@ -62,3 +62,37 @@ func (e ProvisionerRedis) RemoveKey(key string) (bool, error) {
 	}
 	return true, nil
 }
+
+//SetField sets a field of the hash stored at the passed key in redis
+func (e ProvisionerRedis) SetField(key string, field string, value string) error {
+	_, err := e.RedisClient.HSet(key, field, value).Result()
+	return err
+}
+
+//GetField gets a field of the hash stored at the passed key from redis, exists is false if the field is not set
+func (e ProvisionerRedis) GetField(key string, field string) (string, bool, error) {
+	value, err := e.RedisClient.HGet(key, field).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+//GetFields gets all the fields of the hash stored at the passed key from redis
+func (e ProvisionerRedis) GetFields(key string) (map[string]string, error) {
+	return e.RedisClient.HGetAllMap(key).Result()
+}
+
+//RemoveFields removes the passed fields from the hash stored at the passed key in redis
+func (e ProvisionerRedis) RemoveFields(key string, fields ...string) error {
+	_, err := e.RedisClient.HDel(key, fields...).Result()
+	return err
+}
+
+//Close closes the connections to redis
+func (e ProvisionerRedis) Close() error {
+	return e.RedisClient.Close()
+}
@@ -8,4 +8,9 @@ type Provisioner interface {
 	GetValue(string) (string, error)
 	KeyExists(string) (bool, error)
 	RemoveKey(string) (bool, error)
+	SetField(string, string, string) error
+	GetField(string, string) (string, bool, error)
+	GetFields(string) (map[string]string, error)
+	RemoveFields(string, ...string) error
+	Close() error
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/SUSE/cf-usb/lib/brokermodel"
 	"github.com/SUSE/cf-usb/lib/config/redis"
@@ -10,6 +11,19 @@ import (
 
 const usbKey = "usb"
 
+//registryKey is the hash holding the service instances and bindings, apart from the configuration. Every instance
+//and binding is a field of its own, so that USB instances sharing the redis never overwrite each other's records
+const registryKey = "usb-registry"
+
+//Prefixes of the fields of the registry hash
+const (
+	registryInstancePrefix = "instance:"
+	registryBindingPrefix  = "binding:"
+)
+
+//accountsKey holds the broker accounts once they have been changed through the USB
+const accountsKey = "usb-broker-accounts"
+
 type redisConfig struct {
 	provider redis.Provisioner
 }
@@ -307,3 +321,173 @@ func (c *redisConfig) GetPlan(planid string) (*brokermodel.Plan, string, string,
 
 	return nil, "", "", nil
 }
+
+func (c *redisConfig) setRegistryRecord(field string, record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return c.provider.SetField(registryKey, field, string(data))
+}
+
+func (c *redisConfig) getRegistryRecord(field string, record interface{}) (bool, error) {
+	value, exists, err := c.provider.GetField(registryKey, field)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	return true, json.Unmarshal([]byte(value), record)
+}
+
+//getRegistryRecords returns the records of the registry whose field starts with prefix, keyed by their id
+func (c *redisConfig) getRegistryRecords(prefix string) (map[string]string, error) {
+	fields, err := c.provider.GetFields(registryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]string)
+	for field, value := range fields {
+		if strings.HasPrefix(field, prefix) {
+			records[strings.TrimPrefix(field, prefix)] = value
+		}
+	}
+	return records, nil
+}
+
+func (c *redisConfig) getServiceBindings() (map[string]ServiceBinding, error) {
+	records, err := c.getRegistryRecords(registryBindingPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make(map[string]ServiceBinding)
+	for bindingID, value := range records {
+		var binding ServiceBinding
+		err := json.Unmarshal([]byte(value), &binding)
+		if err != nil {
+			return nil, err
+		}
+		bindings[bindingID] = binding
+	}
+	return bindings, nil
+}
+
+func (c *redisConfig) SetServiceInstance(instanceID string, instance ServiceInstance) error {
+	return c.setRegistryRecord(registryInstancePrefix+instanceID, instance)
+}
+
+func (c *redisConfig) GetServiceInstance(instanceID string) (*ServiceInstance, error) {
+	var instance ServiceInstance
+	exists, err := c.getRegistryRecord(registryInstancePrefix+instanceID, &instance)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+func (c *redisConfig) GetServiceInstances() (map[string]ServiceInstance, error) {
+	records, err := c.getRegistryRecords(registryInstancePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make(map[string]ServiceInstance)
+	for instanceID, value := range records {
+		var instance ServiceInstance
+		err := json.Unmarshal([]byte(value), &instance)
+		if err != nil {
+			return nil, err
+		}
+		instances[instanceID] = instance
+	}
+	return instances, nil
+}
+
+//DeleteServiceInstance removes the instance together with the bindings left behind for it
+func (c *redisConfig) DeleteServiceInstance(instanceID string) error {
+	bindings, err := c.getServiceBindings()
+	if err != nil {
+		return err
+	}
+
+	fields := []string{registryInstancePrefix + instanceID}
+	for bindingID, binding := range bindings {
+		if binding.InstanceID == instanceID {
+			fields = append(fields, registryBindingPrefix+bindingID)
+		}
+	}
+
+	return c.provider.RemoveFields(registryKey, fields...)
+}
+
+func (c *redisConfig) SetServiceBinding(bindingID string, binding ServiceBinding) error {
+	return c.setRegistryRecord(registryBindingPrefix+bindingID, binding)
+}
+
+func (c *redisConfig) GetServiceBinding(bindingID string) (*ServiceBinding, error) {
+	var binding ServiceBinding
+	exists, err := c.getRegistryRecord(registryBindingPrefix+bindingID, &binding)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return &binding, nil
+}
+
+func (c *redisConfig) GetServiceBindings(instanceID string) (map[string]ServiceBinding, error) {
+	bindings, err := c.getServiceBindings()
+	if err != nil {
+		return nil, err
+	}
+
+	for bindingID, binding := range bindings {
+		if binding.InstanceID != instanceID {
+			delete(bindings, bindingID)
+		}
+	}
+	return bindings, nil
+}
+
+func (c *redisConfig) DeleteServiceBinding(bindingID string) error {
+	return c.provider.RemoveFields(registryKey, registryBindingPrefix+bindingID)
+}
+
+func (c *redisConfig) GetBrokerAccounts() ([]BrokerAccount, error) {
+	exists, err := c.provider.KeyExists(accountsKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		config, err := c.LoadConfiguration()
+		if err != nil {
+			return nil, err
+		}
+		return config.BrokerAPI.Accounts, nil
+	}
+
+	accountsValue, err := c.provider.GetValue(accountsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBrokerAccounts([]byte(accountsValue))
+}
+
+func (c *redisConfig) SetBrokerAccounts(accounts []BrokerAccount) error {
+	data, err := json.Marshal(accounts)
+	if err != nil {
+		return err
+	}
+
+	return c.provider.SetKV(accountsKey, string(data), 0)
+}
+
+func (c *redisConfig) Ping() error {
+	_, err := c.provider.GetValue(usbKey)
+	return err
+}
+
+func (c *redisConfig) Close() error {
+	return c.provider.Close()
+}
@@ -224,3 +224,61 @@ func Test_Redis_DeleteService(t *testing.T) {
 	err = RedisTestConfig.Provider.DeleteService("A0000000-0000-0000-0000-000000000002")
 	assert.NoError(err)
 }
+
+func Test_Redis_Registry(t *testing.T) {
+	assert := assert.New(t)
+	provisioner := new(redisMock.Provisioner)
+
+	provisioner.On("SetField", "usb-registry", "instance:instance", mock.Anything).Return(nil)
+
+	RedisTestConfig.Provider = NewRedisConfig(provisioner)
+	err := RedisTestConfig.Provider.SetServiceInstance("instance", ServiceInstance{DriverInstanceID: "driver", State: StateSucceeded})
+	assert.NoError(err)
+
+	data := provisioner.Calls[0].Arguments.String(2)
+	assert.Contains(data, `"driver_instance_id":"driver"`)
+
+	provisioner.On("GetFields", "usb-registry").Return(map[string]string{
+		"instance:instance": data,
+		"binding:binding":   `{"instance_id":"instance"}`,
+		"binding:other":     `{"instance_id":"other"}`,
+	}, nil)
+	provisioner.On("GetField", "usb-registry", "binding:missing").Return("", false, nil)
+	provisioner.On("RemoveFields", "usb-registry", []string{"instance:instance", "binding:binding"}).Return(nil)
+
+	instances, err := RedisTestConfig.Provider.GetServiceInstances()
+	assert.NoError(err)
+	assert.Len(instances, 1)
+	assert.Equal("driver", instances["instance"].DriverInstanceID)
+
+	bindings, err := RedisTestConfig.Provider.GetServiceBindings("instance")
+	assert.NoError(err)
+	assert.Len(bindings, 1)
+	assert.Contains(bindings, "binding")
+
+	binding, err := RedisTestConfig.Provider.GetServiceBinding("missing")
+	assert.NoError(err)
+	assert.Nil(binding)
+
+	err = RedisTestConfig.Provider.DeleteServiceInstance("instance")
+	assert.NoError(err)
+	provisioner.AssertCalled(t, "RemoveFields", "usb-registry", []string{"instance:instance", "binding:binding"})
+}
+
+func Test_Redis_SetEmptyBrokerAccounts(t *testing.T) {
+	assert := assert.New(t)
+	provisioner := new(redisMock.Provisioner)
+
+	provisioner.On("SetKV", "usb-broker-accounts", "[]", mock.Anything).Return(nil)
+	provisioner.On("KeyExists", "usb-broker-accounts").Return(true, nil)
+	provisioner.On("GetValue", "usb-broker-accounts").Return("[]", nil)
+
+	RedisTestConfig.Provider = NewRedisConfig(provisioner)
+	err := RedisTestConfig.Provider.SetBrokerAccounts([]BrokerAccount{})
+	assert.NoError(err)
+
+	accounts, err := RedisTestConfig.Provider.GetBrokerAccounts()
+	assert.NoError(err)
+	assert.Empty(accounts)
+	provisioner.AssertNotCalled(t, "GetValue", "usb")
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+//registryStore loads and saves a whole Registry, it backs the file provider that keeps the registry as a single
+//document. The lock guards each read-modify-write of the document within the process
+type registryStore interface {
+	sync.Locker
+	loadRegistry() (*Registry, error)
+	saveRegistry(registry *Registry) error
+}
+
+func newRegistry() *Registry {
+	return &Registry{
+		ServiceInstances: make(map[string]ServiceInstance),
+		ServiceBindings:  make(map[string]ServiceBinding),
+	}
+}
+
+func parseRegistry(data []byte) (*Registry, error) {
+	registry := newRegistry()
+	if len(data) == 0 {
+		return registry, nil
+	}
+
+	err := json.Unmarshal(data, registry)
+	if err != nil {
+		return nil, err
+	}
+	if registry.ServiceInstances == nil {
+		registry.ServiceInstances = make(map[string]ServiceInstance)
+	}
+	if registry.ServiceBindings == nil {
+		registry.ServiceBindings = make(map[string]ServiceBinding)
+	}
+	return registry, nil
+}
+
+func setServiceInstance(store registryStore, instanceID string, instance ServiceInstance) error {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	registry.ServiceInstances[instanceID] = instance
+
+	return store.saveRegistry(registry)
+}
+
+func getServiceInstance(store registryStore, instanceID string) (*ServiceInstance, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := registry.ServiceInstances[instanceID]
+	if !ok {
+		return nil, nil
+	}
+	return &instance, nil
+}
+
+func getServiceInstances(store registryStore) (map[string]ServiceInstance, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.ServiceInstances, nil
+}
+
+//deleteServiceInstance removes the instance together with the bindings left behind for it
+func deleteServiceInstance(store registryStore, instanceID string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	delete(registry.ServiceInstances, instanceID)
+	for bindingID, binding := range registry.ServiceBindings {
+		if binding.InstanceID == instanceID {
+			delete(registry.ServiceBindings, bindingID)
+		}
+	}
+
+	return store.saveRegistry(registry)
+}
+
+func setServiceBinding(store registryStore, bindingID string, binding ServiceBinding) error {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	registry.ServiceBindings[bindingID] = binding
+
+	return store.saveRegistry(registry)
+}
+
+func getServiceBinding(store registryStore, bindingID string) (*ServiceBinding, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	binding, ok := registry.ServiceBindings[bindingID]
+	if !ok {
+		return nil, nil
+	}
+	return &binding, nil
+}
+
+func getServiceBindings(store registryStore, instanceID string) (map[string]ServiceBinding, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make(map[string]ServiceBinding)
+	for bindingID, binding := range registry.ServiceBindings {
+		if binding.InstanceID == instanceID {
+			bindings[bindingID] = binding
+		}
+	}
+	return bindings, nil
+}
+
+func deleteServiceBinding(store registryStore, bindingID string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	registry, err := store.loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	delete(registry.ServiceBindings, bindingID)
+
+	return store.saveRegistry(registry)
+}
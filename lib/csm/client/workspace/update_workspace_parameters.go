@@ -0,0 +1,73 @@
+package workspace
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+)
+
+// NewUpdateWorkspaceParams creates a new UpdateWorkspaceParams object
+// with the default values initialized.
+func NewUpdateWorkspaceParams() *UpdateWorkspaceParams {
+	var ()
+	return &UpdateWorkspaceParams{}
+}
+
+/*UpdateWorkspaceParams contains all the parameters to send to the API endpoint
+for the update workspace operation typically these are written to a http.Request
+*/
+type UpdateWorkspaceParams struct {
+
+	/*UpdateWorkspaceRequest
+	  The new details of the workspace
+
+	*/
+	UpdateWorkspaceRequest *models.ServiceManagerWorkspaceUpdateRequest
+	/*WorkspaceID
+	  Workspace ID
+
+	*/
+	WorkspaceID string
+}
+
+// WithUpdateWorkspaceRequest adds the updateWorkspaceRequest to the update workspace params
+func (o *UpdateWorkspaceParams) WithUpdateWorkspaceRequest(UpdateWorkspaceRequest *models.ServiceManagerWorkspaceUpdateRequest) *UpdateWorkspaceParams {
+	o.UpdateWorkspaceRequest = UpdateWorkspaceRequest
+	return o
+}
+
+// WithWorkspaceID adds the workspaceId to the update workspace params
+func (o *UpdateWorkspaceParams) WithWorkspaceID(WorkspaceID string) *UpdateWorkspaceParams {
+	o.WorkspaceID = WorkspaceID
+	return o
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *UpdateWorkspaceParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	var res []error
+
+	if o.UpdateWorkspaceRequest == nil {
+		o.UpdateWorkspaceRequest = new(models.ServiceManagerWorkspaceUpdateRequest)
+	}
+
+	if err := r.SetBodyParam(o.UpdateWorkspaceRequest); err != nil {
+		return err
+	}
+
+	// path param workspace_id
+	if err := r.SetPathParam("workspace_id", o.WorkspaceID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package workspace
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+)
+
+// UpdateWorkspaceReader is a Reader for the UpdateWorkspace structure.
+type UpdateWorkspaceReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the recieved o.
+func (o *UpdateWorkspaceReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewUpdateWorkspaceOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		result := NewUpdateWorkspaceDefault(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	}
+}
+
+// NewUpdateWorkspaceOK creates a UpdateWorkspaceOK with default headers values
+func NewUpdateWorkspaceOK() *UpdateWorkspaceOK {
+	return &UpdateWorkspaceOK{}
+}
+
+/*UpdateWorkspaceOK handles this case with default header values.
+
+update workspace
+*/
+type UpdateWorkspaceOK struct {
+	Payload *models.ServiceManagerWorkspaceResponse
+}
+
+func (o *UpdateWorkspaceOK) Error() string {
+	return fmt.Sprintf("[PATCH /workspaces/{workspace_id}][%d] updateWorkspaceOK  %+v", 200, o.Payload)
+}
+
+func (o *UpdateWorkspaceOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ServiceManagerWorkspaceResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateWorkspaceDefault creates a UpdateWorkspaceDefault with default headers values
+func NewUpdateWorkspaceDefault(code int) *UpdateWorkspaceDefault {
+	return &UpdateWorkspaceDefault{
+		_statusCode: code,
+	}
+}
+
+/*UpdateWorkspaceDefault handles this case with default header values.
+
+generic error response
+*/
+type UpdateWorkspaceDefault struct {
+	_statusCode int
+
+	Payload *models.Error
+}
+
+// Code gets the status code for the get workspace default response
+func (o *UpdateWorkspaceDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *UpdateWorkspaceDefault) Error() string {
+	return fmt.Sprintf("[PATCH /workspaces/{workspace_id}][%d] updateWorkspace default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *UpdateWorkspaceDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -100,6 +100,32 @@ func (a *Client) GetWorkspace(params *GetWorkspaceParams, authInfo runtime.Clien
 	return result.(*GetWorkspaceOK), nil
 }
 
+/*
+UpdateWorkspace Update the details of the specified workspace
+*/
+func (a *Client) UpdateWorkspace(params *UpdateWorkspaceParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateWorkspaceOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateWorkspaceParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateWorkspace",
+		Method:             "PATCH",
+		PathPattern:        "/workspaces/{workspace_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UpdateWorkspaceReader{formats: a.formats},
+		AuthInfo:           authInfo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*UpdateWorkspaceOK), nil
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -0,0 +1,55 @@
+package csm
+
+import (
+	"sync"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type cachedClient struct {
+	endpoint Endpoint
+	client   CSM
+}
+
+type clientCache struct {
+	sync.Mutex
+	logger  lager.Logger
+	clients map[string]cachedClient
+}
+
+//NewClientCache instantiates a new ClientCache
+func NewClientCache(logger lager.Logger) ClientCache {
+	cache := clientCache{}
+	cache.logger = logger
+	cache.clients = make(map[string]cachedClient)
+	return &cache
+}
+
+//GetClient returns the client cached for driverInstanceID, a new client is created when
+//there is none yet or when the endpoint of the driver instance has changed
+func (cache *clientCache) GetClient(driverInstanceID string, endpoint Endpoint) (CSM, error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	cached, ok := cache.clients[driverInstanceID]
+	if ok && cached.endpoint == endpoint {
+		return cached.client, nil
+	}
+
+	client, err := NewCSMClient(cache.logger, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client = instrument(client, endpoint.Name)
+	cache.clients[driverInstanceID] = cachedClient{endpoint: endpoint, client: client}
+	return client, nil
+}
+
+//Remove drops the client cached for driverInstanceID, it is called once the driver instance is gone
+func (cache *clientCache) Remove(driverInstanceID string) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	delete(cache.clients, driverInstanceID)
+}
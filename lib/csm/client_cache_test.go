@@ -0,0 +1,52 @@
+package csm
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCacheReusesClients(t *testing.T) {
+	assert := assert.New(t)
+	cache := NewClientCache(logger)
+
+	endpoint := Endpoint{TargetURL: "https://csm.example.com", AuthenticationKey: "key"}
+
+	clients := make([]CSM, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := cache.GetClient("driver-instance", endpoint)
+			assert.NoError(err)
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	for _, client := range clients {
+		assert.True(client == clients[0])
+	}
+
+	other, err := cache.GetClient("other-driver-instance", endpoint)
+	assert.NoError(err)
+	assert.False(other == clients[0])
+}
+
+func TestClientCacheRenewsClientOnEndpointChange(t *testing.T) {
+	assert := assert.New(t)
+	cache := NewClientCache(logger)
+
+	client, err := cache.GetClient("driver-instance", Endpoint{TargetURL: "https://csm.example.com", AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	renewed, err := cache.GetClient("driver-instance", Endpoint{TargetURL: "https://csm.example.com", AuthenticationKey: "rotated"})
+	assert.NoError(err)
+	assert.False(client == renewed)
+
+	cached, err := cache.GetClient("driver-instance", Endpoint{TargetURL: "https://csm.example.com", AuthenticationKey: "rotated"})
+	assert.NoError(err)
+	assert.True(renewed == cached)
+}
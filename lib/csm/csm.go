@@ -16,6 +16,7 @@ import (
 	"github.com/go-openapi/runtime"
 	runtimeClient "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -25,70 +26,65 @@ type csmClient struct {
 	connectionClient *connection.Client
 	statusClient     *status.Client
 	authInfoWriter   runtime.ClientAuthInfoWriter
-	loggedIn         bool
 }
 
-//NewCSMClient instantiates a new csmClient
-func NewCSMClient(logger lager.Logger) CSM {
-	csm := csmClient{}
-	csm.logger = logger
-	csm.loggedIn = false
-	return &csm
-}
+//OriginatingIdentityHeader carries the platform user on whose behalf a request is made
+const OriginatingIdentityHeader string = "X-Broker-API-Originating-Identity"
 
-func (csm *csmClient) Login(targetEndpoint string, token string, caCert string, skipSSLValidation bool) error {
-	csm.logger.Info("csm-login", lager.Data{"endpoint": targetEndpoint})
-	target, err := url.Parse(targetEndpoint)
+//RequestIDHeader carries the id that correlates the requests made on behalf of a single broker request
+const RequestIDHeader string = "X-Request-ID"
+
+//NewCSMClient instantiates a new csmClient bound to the given endpoint
+func NewCSMClient(logger lager.Logger, endpoint Endpoint) (CSM, error) {
+	logger.Info("csm-new-client", lager.Data{"endpoint": endpoint.TargetURL})
+	target, err := url.Parse(endpoint.TargetURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	transport := runtimeClient.New(target.Host, "/", []string{target.Scheme})
 
-	if skipSSLValidation {
+	if endpoint.SkipSSLValidation {
 		transport.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
 	} else {
-		if strings.TrimSpace(caCert) != "" {
+		if strings.TrimSpace(endpoint.CaCert) != "" {
 			caCertPool := x509.NewCertPool()
-			caCertPool.AppendCertsFromPEM([]byte(caCert))
+			caCertPool.AppendCertsFromPEM([]byte(endpoint.CaCert))
 			transport.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: caCertPool}}
 		}
 	}
+	transport.Transport = &errorBodyTransport{transport: transport.Transport}
 
+	csm := csmClient{}
+	csm.logger = logger
 	csm.workspaceCient = workspace.New(transport, strfmt.Default)
 	csm.statusClient = status.New(transport, strfmt.Default)
 	csm.connectionClient = connection.New(transport, strfmt.Default)
-	csm.authInfoWriter = runtimeClient.APIKeyAuth("x-csm-token", "header", token)
-	csm.loggedIn = true
-	return nil
+	csm.authInfoWriter = runtimeClient.APIKeyAuth("x-csm-token", "header", endpoint.AuthenticationKey)
+	return &csm, nil
 }
 
-func (csm *csmClient) CreateWorkspace(workspaceID string) error {
-	if !csm.loggedIn {
-		return errors.New("Not logged in")
-	}
+func (csm *csmClient) CreateWorkspace(workspaceID string, details map[string]interface{}) (*models.ServiceManagerWorkspaceResponse, error) {
 	csm.logger.Info("csm-create-workspace", lager.Data{"workspaceID": workspaceID})
 	request := models.ServiceManagerWorkspaceCreateRequest{
 		WorkspaceID: &workspaceID,
+		Details:     details,
 	}
 	params := workspace.CreateWorkspaceParams{}
 	params.CreateWorkspaceRequest = &request
-	_, err := csm.workspaceCient.CreateWorkspace(&params, csm.authInfoWriter)
+	response, err := csm.workspaceCient.CreateWorkspace(&params, csm.authInfoWriter)
 
 	if err != nil {
 		csmError, ok := err.(*workspace.CreateWorkspaceDefault)
 		if !ok {
-			return err
+			return nil, err
 		}
-		return fmt.Errorf(*csmError.Payload.Message)
+		return nil, &Error{StatusCode: csmError.Code(), Message: errorMessage(csmError.Code(), csmError.Payload)}
 	}
 
-	return nil
+	return response.Payload, nil
 
 }
 func (csm *csmClient) WorkspaceExists(workspaceID string) (bool, bool, error) {
-	if !csm.loggedIn {
-		return false, false, errors.New("Not logged in")
-	}
 	csm.logger.Info("csm-workspace-exists", lager.Data{"workspaceID": workspaceID})
 
 	params := workspace.GetWorkspaceParams{}
@@ -104,7 +100,7 @@ func (csm *csmClient) WorkspaceExists(workspaceID string) (bool, bool, error) {
 		if csmError.Code() == http.StatusNotFound {
 			return false, false, nil
 		}
-		return false, false, fmt.Errorf(*csmError.Payload.Message)
+		return false, false, errors.New(errorMessage(csmError.Code(), csmError.Payload))
 	}
 
 	if response != nil {
@@ -121,10 +117,50 @@ func (csm *csmClient) WorkspaceExists(workspaceID string) (bool, bool, error) {
 
 }
 
-func (csm *csmClient) DeleteWorkspace(workspaceID string) error {
-	if !csm.loggedIn {
-		return errors.New("Not logged in")
+func (csm *csmClient) GetWorkspace(workspaceID string) (*models.ServiceManagerWorkspaceResponse, error) {
+	csm.logger.Info("csm-get-workspace", lager.Data{"workspaceID": workspaceID})
+
+	params := workspace.GetWorkspaceParams{}
+	params.WorkspaceID = workspaceID
+	response, err := csm.workspaceCient.GetWorkspace(&params, csm.authInfoWriter)
+
+	if err != nil {
+		csmError, ok := err.(*workspace.GetWorkspaceDefault)
+		if !ok {
+			return nil, err
+		}
+
+		if csmError.Code() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.New(errorMessage(csmError.Code(), csmError.Payload))
+	}
+
+	return response.Payload, nil
+}
+
+func (csm *csmClient) UpdateWorkspace(workspaceID string, details map[string]interface{}) error {
+	csm.logger.Info("csm-update-workspace", lager.Data{"workspaceID": workspaceID})
+	request := models.ServiceManagerWorkspaceUpdateRequest{
+		Details: details,
+	}
+	params := workspace.UpdateWorkspaceParams{}
+	params.WorkspaceID = workspaceID
+	params.UpdateWorkspaceRequest = &request
+	_, err := csm.workspaceCient.UpdateWorkspace(&params, csm.authInfoWriter)
+
+	if err != nil {
+		csmError, ok := err.(*workspace.UpdateWorkspaceDefault)
+		if !ok {
+			return err
+		}
+		return errors.New(errorMessage(csmError.Code(), csmError.Payload))
 	}
+
+	return nil
+}
+
+func (csm *csmClient) DeleteWorkspace(workspaceID string) error {
 	csm.logger.Info("csm-delete-workspace", lager.Data{"workspaceID": workspaceID})
 	params := workspace.DeleteWorkspaceParams{}
 	params.WorkspaceID = workspaceID
@@ -135,7 +171,7 @@ func (csm *csmClient) DeleteWorkspace(workspaceID string) error {
 		if !ok {
 			return err
 		}
-		return fmt.Errorf(*csmError.Payload.Message)
+		return errors.New(errorMessage(csmError.Code(), csmError.Payload))
 	}
 
 	//TODO: does not throw an error if the workspace does not exist
@@ -143,16 +179,14 @@ func (csm *csmClient) DeleteWorkspace(workspaceID string) error {
 
 }
 
-func (csm *csmClient) CreateConnection(workspaceID, connectionID string) (interface{}, error) {
-	if !csm.loggedIn {
-		return nil, errors.New("Not logged in")
-	}
+func (csm *csmClient) CreateConnection(workspaceID, connectionID string, details map[string]interface{}) (interface{}, error) {
 	csm.logger.Info("csm-create-connection", lager.Data{"workspaceID": workspaceID, "connectionID": connectionID})
 	params := connection.CreateConnectionParams{}
 	params.WorkspaceID = workspaceID
 
 	request := models.ServiceManagerConnectionCreateRequest{
 		ConnectionID: &connectionID,
+		Details:      details,
 	}
 
 	params.ConnectionCreateRequest = &request
@@ -162,7 +196,7 @@ func (csm *csmClient) CreateConnection(workspaceID, connectionID string) (interf
 		if !ok {
 			return nil, err
 		}
-		return nil, fmt.Errorf(*csmError.Payload.Message)
+		return nil, &Error{StatusCode: csmError.Code(), Message: errorMessage(csmError.Code(), csmError.Payload)}
 	}
 
 	if response.Payload.Details == nil {
@@ -174,9 +208,6 @@ func (csm *csmClient) CreateConnection(workspaceID, connectionID string) (interf
 }
 
 func (csm *csmClient) ConnectionExists(workspaceID, connectionID string) (bool, bool, error) {
-	if !csm.loggedIn {
-		return false, false, errors.New("Not logged in")
-	}
 	csm.logger.Info("csm-connection-exists", lager.Data{"workspaceID": workspaceID, "connectionID": connectionID})
 	params := connection.GetConnectionParams{
 		WorkspaceID:  workspaceID,
@@ -193,7 +224,7 @@ func (csm *csmClient) ConnectionExists(workspaceID, connectionID string) (bool,
 		if csmError.Code() == http.StatusNotFound {
 			return false, false, nil
 		}
-		return false, false, fmt.Errorf(*csmError.Payload.Message)
+		return false, false, errors.New(errorMessage(csmError.Code(), csmError.Payload))
 	}
 
 	if response != nil {
@@ -210,10 +241,30 @@ func (csm *csmClient) ConnectionExists(workspaceID, connectionID string) (bool,
 
 }
 
-func (csm *csmClient) DeleteConnection(workspaceID, connectionID string) error {
-	if !csm.loggedIn {
-		return errors.New("Not logged in")
+func (csm *csmClient) GetConnection(workspaceID, connectionID string) (*models.ServiceManagerConnectionResponse, error) {
+	csm.logger.Info("csm-get-connection", lager.Data{"workspaceID": workspaceID, "connectionID": connectionID})
+	params := connection.GetConnectionParams{
+		WorkspaceID:  workspaceID,
+		ConnectionID: connectionID,
 	}
+
+	response, err := csm.connectionClient.GetConnection(&params, csm.authInfoWriter)
+
+	if err != nil {
+		csmError, ok := err.(*connection.GetConnectionDefault)
+		if !ok {
+			return nil, err
+		}
+		if csmError.Code() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.New(errorMessage(csmError.Code(), csmError.Payload))
+	}
+
+	return response.Payload, nil
+}
+
+func (csm *csmClient) DeleteConnection(workspaceID, connectionID string) error {
 	csm.logger.Info("csm-delete-connection", lager.Data{"workspaceID": workspaceID, "connectionID": connectionID})
 	params := connection.DeleteConnectionParams{
 		WorkspaceID:  workspaceID,
@@ -226,7 +277,7 @@ func (csm *csmClient) DeleteConnection(workspaceID, connectionID string) error {
 		if !ok {
 			return err
 		}
-		return fmt.Errorf(*csmError.Payload.Message)
+		return errors.New(errorMessage(csmError.Code(), csmError.Payload))
 	}
 
 	//TODO: in CSM this passes all the time, it does not take into consideration if a connection exists
@@ -235,31 +286,65 @@ func (csm *csmClient) DeleteConnection(workspaceID, connectionID string) error {
 }
 
 func (csm *csmClient) GetStatus() (string, error) {
-	if !csm.loggedIn {
-		return "", errors.New("Not logged in")
+	response, err := csm.GetStatusResponse()
+	if err != nil {
+		return "", err
+	}
+	csm.logger.Info("status-response", lager.Data{"Status ": response.Status, "Message": response.Message, "Processing Type": response.ProcessingType})
+
+	if swag.StringValue(response.Status) == "failed" {
+
+		errTrace := swag.StringValue(response.Message)
+		for _, diag := range response.Diagnostics {
+			if diag == nil {
+				continue
+			}
+			csm.logger.Debug("status-response-diagnostics", lager.Data{"Status": diag.Status, "Message": diag.Message, "Name": diag.Name, "Description": diag.Description})
+			errTrace = errTrace + fmt.Sprintf("\n Status: %s, Name: %s, Description: %s, Message: %s",
+				swag.StringValue(diag.Status), swag.StringValue(diag.Name), swag.StringValue(diag.Description), swag.StringValue(diag.Message))
+		}
+
+		return "", errors.New(errTrace)
 	}
+	return response.ServiceType, nil
+}
+
+//GetStatusResponse returns the status reported by the CSM along with its diagnostics, a failed status is not an error
+func (csm *csmClient) GetStatusResponse() (*models.StatusResponse, error) {
 	params := status.NewStatusParams()
 	response, err := csm.statusClient.Status(params, csm.authInfoWriter)
 	if err != nil {
 		csmError, ok := err.(*status.StatusDefault)
 		if !ok {
-			return "", err
+			return nil, err
 		}
-		return "", fmt.Errorf(*csmError.Payload.Message)
+		return nil, &Error{StatusCode: csmError.Code(), Message: errorMessage(csmError.Code(), csmError.Payload)}
 	}
-	csm.logger.Info("status-response", lager.Data{"Status ": response.Payload.Status, "Message": response.Payload.Message, "Processing Type": response.Payload.ProcessingType})
+	return response.Payload, nil
+}
 
-	if response != nil {
-		if *response.Payload.Status == "failed" {
+//WithOriginatingIdentity returns a copy of the client that forwards the given originating identity to the CSM
+func (csm *csmClient) WithOriginatingIdentity(identity string) CSM {
+	return csm.withHeader(OriginatingIdentityHeader, identity)
+}
 
-			errTrace := *response.Payload.Message
-			for _, diag := range response.Payload.Diagnostics {
-				csm.logger.Debug("status-response-diagnostics", lager.Data{"Status": diag.Status, "Message": diag.Message, "Name": diag.Name, "Description": diag.Description})
-				errTrace = errTrace + fmt.Sprintf("\n Status: %s, Name: %s, Description: %s, Message: %s", *diag.Status, *diag.Name, *diag.Description, *diag.Message)
-			}
+//WithRequestID returns a copy of the client that sends the given request id to the CSM
+func (csm *csmClient) WithRequestID(requestID string) CSM {
+	return csm.withHeader(RequestIDHeader, requestID)
+}
 
-			return "", fmt.Errorf(errTrace)
-		}
+func (csm *csmClient) withHeader(name string, value string) CSM {
+	if value == "" {
+		return csm
 	}
-	return response.Payload.ServiceType, nil
+
+	authInfoWriter := csm.authInfoWriter
+	client := *csm
+	client.authInfoWriter = runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, formats strfmt.Registry) error {
+		if err := r.SetHeaderParam(name, value); err != nil {
+			return err
+		}
+		return authInfoWriter.AuthenticateRequest(r, formats)
+	})
+	return &client
 }
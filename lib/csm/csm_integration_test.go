@@ -16,13 +16,12 @@ var csmEndpoint string
 var authToken string
 
 func getCSMClient() (CSM, error) {
-	client := NewCSMClient(logger)
 	//skipping SSL validation
-	err := client.Login(csmEndpoint, authToken, "", true)
-	if err != nil {
-		return nil, err
-	}
-	return client, nil
+	return NewCSMClient(logger, Endpoint{
+		TargetURL:         csmEndpoint,
+		AuthenticationKey: authToken,
+		SkipSSLValidation: true,
+	})
 }
 
 func TestCSMClient(t *testing.T) {
@@ -41,7 +40,7 @@ func TestCSMClient(t *testing.T) {
 		assert.Fail(err.Error())
 	}
 
-	err = client.CreateWorkspace(workspaceID)
+	_, err = client.CreateWorkspace(workspaceID, nil)
 	time.Sleep(120 * time.Second)
 	assert.Nil(err)
 
@@ -51,7 +50,7 @@ func TestCSMClient(t *testing.T) {
 		assert.True(exists)
 	}
 
-	credentials, err := client.CreateConnection(workspaceID, connectionID)
+	credentials, err := client.CreateConnection(workspaceID, connectionID, nil)
 	time.Sleep(120 * time.Second)
 	assert.Nil(err)
 	assert.NotNil(credentials)
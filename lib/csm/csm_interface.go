@@ -1,13 +1,35 @@
 package csm
 
+import "github.com/SUSE/cf-usb/lib/csm/models"
+
+//Endpoint holds the connection information of a CSM
+type Endpoint struct {
+	Name              string
+	TargetURL         string
+	AuthenticationKey string
+	CaCert            string
+	SkipSSLValidation bool
+}
+
 //CSM is the model to use for implementing a new CSM client
 type CSM interface {
-	Login(string, string, string, bool) error
-	CreateWorkspace(string) error
+	CreateWorkspace(string, map[string]interface{}) (*models.ServiceManagerWorkspaceResponse, error)
 	WorkspaceExists(string) (bool, bool, error)
+	GetWorkspace(string) (*models.ServiceManagerWorkspaceResponse, error)
+	UpdateWorkspace(string, map[string]interface{}) error
 	DeleteWorkspace(string) error
-	CreateConnection(string, string) (interface{}, error)
+	CreateConnection(string, string, map[string]interface{}) (interface{}, error)
 	ConnectionExists(string, string) (bool, bool, error)
+	GetConnection(string, string) (*models.ServiceManagerConnectionResponse, error)
 	DeleteConnection(string, string) error
 	GetStatus() (string, error)
+	GetStatusResponse() (*models.StatusResponse, error)
+	WithOriginatingIdentity(string) CSM
+	WithRequestID(string) CSM
+}
+
+//ClientCache hands out the CSM client of a driver instance, creating it on first use
+type ClientCache interface {
+	GetClient(string, Endpoint) (CSM, error)
+	Remove(string)
 }
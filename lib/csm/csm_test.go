@@ -0,0 +1,178 @@
+package csm
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOriginatingIdentityForwardsHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	headers := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	identity := "cloudfoundry eyJ1c2VyX2lkIjoiNjgzZWE3NDgtMzA5Mi00ZmY0LWI2NTYtMzljYWNjNGQ1MzYwIn0="
+	_, err = client.WithOriginatingIdentity(identity).GetWorkspace("workspace")
+	assert.NoError(err)
+
+	header := <-headers
+	assert.Equal(identity, header.Get(OriginatingIdentityHeader))
+	assert.Equal("key", header.Get("x-csm-token"))
+
+	_, err = client.GetWorkspace("workspace")
+	assert.NoError(err)
+
+	header = <-headers
+	assert.Empty(header.Get(OriginatingIdentityHeader))
+}
+
+func TestWithRequestIDSendsHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	_, err = client.WithRequestID("request").WithOriginatingIdentity("cloudfoundry e30=").GetWorkspace("workspace")
+	assert.NoError(err)
+
+	header := <-headers
+	assert.Equal("request", header.Get(RequestIDHeader))
+	assert.Equal("cloudfoundry e30=", header.Get(OriginatingIdentityHeader))
+	assert.Equal("key", header.Get("x-csm-token"))
+}
+
+func TestGetStatusResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("x-csm-token") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"failed","message":"database down","processing_type":"default","service_type":"mysql",
+			"diagnostics":[{"name":"connect","description":"connects to the database","status":"failed","message":"refused"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	status, err := client.GetStatusResponse()
+	assert.NoError(err)
+	assert.Equal("failed", *status.Status)
+	assert.Equal("mysql", status.ServiceType)
+	assert.Len(status.Diagnostics, 1)
+	assert.Equal("refused", *status.Diagnostics[0].Message)
+
+	_, err = client.GetStatus()
+	assert.Error(err)
+
+	client, err = NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "wrong"})
+	assert.NoError(err)
+
+	_, err = client.GetStatusResponse()
+	assert.Equal(&Error{StatusCode: http.StatusUnauthorized, Message: "invalid token"}, err)
+}
+
+func TestWorkspaceCallsWithoutErrorMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	_, err = client.CreateWorkspace("workspace", nil)
+	assert.Equal(&Error{StatusCode: http.StatusBadGateway, Message: "The CSM answered with 502 Bad Gateway"}, err)
+	assert.True(IsAmbiguous(err))
+
+	_, err = client.GetWorkspace("workspace")
+	assert.EqualError(err, "The CSM answered with 502 Bad Gateway")
+
+	_, _, err = client.WorkspaceExists("workspace")
+	assert.EqualError(err, "The CSM answered with 502 Bad Gateway")
+
+	err = client.DeleteWorkspace("workspace")
+	assert.EqualError(err, "The CSM answered with 502 Bad Gateway")
+
+	err = client.UpdateWorkspace("workspace", map[string]interface{}{"size": "10G"})
+	assert.EqualError(err, "The CSM answered with 502 Bad Gateway")
+}
+
+func TestConnectionCallsWithoutErrorMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	_, err = client.CreateConnection("workspace", "connection", nil)
+	assert.Equal(&Error{StatusCode: http.StatusInternalServerError, Message: "The CSM answered with 500 Internal Server Error"}, err)
+
+	_, err = client.GetConnection("workspace", "connection")
+	assert.EqualError(err, "The CSM answered with 500 Internal Server Error")
+
+	_, _, err = client.ConnectionExists("workspace", "connection")
+	assert.EqualError(err, "The CSM answered with 500 Internal Server Error")
+
+	err = client.DeleteConnection("workspace", "connection")
+	assert.EqualError(err, "The CSM answered with 500 Internal Server Error")
+}
+
+func TestIsTLSError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, err := NewCSMClient(logger, Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	_, err = client.GetStatusResponse()
+	assert.True(IsTLSError(err))
+
+	assert.False(IsTLSError(nil))
+	assert.False(IsTLSError(errors.New("connection refused")))
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsAmbiguous(nil))
+	assert.False(IsAmbiguous(&Error{StatusCode: http.StatusConflict, Message: "exists"}))
+	assert.True(IsAmbiguous(&Error{StatusCode: http.StatusBadGateway, Message: "gateway"}))
+	assert.True(IsAmbiguous(errors.New("connection reset by peer")))
+}
@@ -0,0 +1,102 @@
+package csm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+)
+
+//Error is a failure reported by the CSM in its response
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+//errorMessage is the message of an error the CSM answered with, or a description of the status of the response
+//when the CSM sent no message
+func errorMessage(code int, payload *models.Error) string {
+	if payload != nil && payload.Message != nil && *payload.Message != "" {
+		return *payload.Message
+	}
+	if text := http.StatusText(code); text != "" {
+		return fmt.Sprintf("The CSM answered with %d %s", code, text)
+	}
+	return fmt.Sprintf("The CSM answered with status %d", code)
+}
+
+//errorBodyTransport replaces the body of error responses that are not a CSM error, such as the empty or HTML pages
+//proxies in front of the CSM answer with, by an empty JSON object, so that the status of the response is still
+//reported instead of a decoding error
+type errorBodyTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *errorBodyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err != nil || response.StatusCode < http.StatusBadRequest {
+		return response, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var payload models.Error
+	if json.Unmarshal(body, &payload) != nil {
+		body = []byte("{}")
+	}
+	response.Header.Set("Content-Type", "application/json")
+	response.ContentLength = int64(len(body))
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return response, nil
+}
+
+//IsAmbiguous reports whether a failed CSM call may still have taken effect on the sidecar.
+//Transport errors, timeouts and server errors are ambiguous, errors the CSM rejected the request with are not
+func IsAmbiguous(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	csmError, ok := err.(*Error)
+	if !ok {
+		return true
+	}
+	return csmError.StatusCode >= http.StatusInternalServerError
+}
+
+//IsTLSError reports whether a CSM call failed because no TLS connection could be established with the CSM,
+//for example because its certificate is not trusted or does not match its host
+func IsTLSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalidCertificate x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var recordHeader tls.RecordHeaderError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &invalidCertificate) ||
+		errors.As(err, &hostname) || errors.As(err, &recordHeader) {
+		return true
+	}
+
+	// Handshake failures such as alerts sent by the CSM are not exported by the TLS stack
+	message := err.Error()
+	return strings.Contains(message, "x509: ") || strings.Contains(message, "tls: ")
+}
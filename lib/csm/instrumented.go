@@ -0,0 +1,107 @@
+package csm
+
+import (
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/csm/models"
+	"github.com/SUSE/cf-usb/lib/metrics"
+)
+
+//instrumentedClient records the latency and errors of the calls made through client under the name of its driver endpoint
+type instrumentedClient struct {
+	driverEndpoint string
+	client         CSM
+}
+
+func instrument(client CSM, driverEndpoint string) CSM {
+	return &instrumentedClient{driverEndpoint: driverEndpoint, client: client}
+}
+
+func (csm *instrumentedClient) observe(operation string, start time.Time, err error) {
+	metrics.ObserveCSMRequest(csm.driverEndpoint, operation, time.Since(start), err)
+}
+
+func (csm *instrumentedClient) CreateWorkspace(workspaceID string, details map[string]interface{}) (*models.ServiceManagerWorkspaceResponse, error) {
+	start := time.Now()
+	response, err := csm.client.CreateWorkspace(workspaceID, details)
+	csm.observe("create_workspace", start, err)
+	return response, err
+}
+
+func (csm *instrumentedClient) WorkspaceExists(workspaceID string) (bool, bool, error) {
+	start := time.Now()
+	exists, noop, err := csm.client.WorkspaceExists(workspaceID)
+	csm.observe("workspace_exists", start, err)
+	return exists, noop, err
+}
+
+func (csm *instrumentedClient) GetWorkspace(workspaceID string) (*models.ServiceManagerWorkspaceResponse, error) {
+	start := time.Now()
+	response, err := csm.client.GetWorkspace(workspaceID)
+	csm.observe("get_workspace", start, err)
+	return response, err
+}
+
+func (csm *instrumentedClient) UpdateWorkspace(workspaceID string, details map[string]interface{}) error {
+	start := time.Now()
+	err := csm.client.UpdateWorkspace(workspaceID, details)
+	csm.observe("update_workspace", start, err)
+	return err
+}
+
+func (csm *instrumentedClient) DeleteWorkspace(workspaceID string) error {
+	start := time.Now()
+	err := csm.client.DeleteWorkspace(workspaceID)
+	csm.observe("delete_workspace", start, err)
+	return err
+}
+
+func (csm *instrumentedClient) CreateConnection(workspaceID, connectionID string, details map[string]interface{}) (interface{}, error) {
+	start := time.Now()
+	response, err := csm.client.CreateConnection(workspaceID, connectionID, details)
+	csm.observe("create_connection", start, err)
+	return response, err
+}
+
+func (csm *instrumentedClient) ConnectionExists(workspaceID string, connectionID string) (bool, bool, error) {
+	start := time.Now()
+	exists, noop, err := csm.client.ConnectionExists(workspaceID, connectionID)
+	csm.observe("connection_exists", start, err)
+	return exists, noop, err
+}
+
+func (csm *instrumentedClient) GetConnection(workspaceID string, connectionID string) (*models.ServiceManagerConnectionResponse, error) {
+	start := time.Now()
+	response, err := csm.client.GetConnection(workspaceID, connectionID)
+	csm.observe("get_connection", start, err)
+	return response, err
+}
+
+func (csm *instrumentedClient) DeleteConnection(workspaceID string, connectionID string) error {
+	start := time.Now()
+	err := csm.client.DeleteConnection(workspaceID, connectionID)
+	csm.observe("delete_connection", start, err)
+	return err
+}
+
+func (csm *instrumentedClient) GetStatus() (string, error) {
+	start := time.Now()
+	status, err := csm.client.GetStatus()
+	csm.observe("get_status", start, err)
+	return status, err
+}
+
+func (csm *instrumentedClient) GetStatusResponse() (*models.StatusResponse, error) {
+	start := time.Now()
+	response, err := csm.client.GetStatusResponse()
+	csm.observe("get_status", start, err)
+	return response, err
+}
+
+func (csm *instrumentedClient) WithOriginatingIdentity(identity string) CSM {
+	return instrument(csm.client.WithOriginatingIdentity(identity), csm.driverEndpoint)
+}
+
+func (csm *instrumentedClient) WithRequestID(requestID string) CSM {
+	return instrument(csm.client.WithRequestID(requestID), csm.driverEndpoint)
+}
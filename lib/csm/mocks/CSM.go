@@ -2,36 +2,34 @@ package mocks
 
 import "github.com/stretchr/testify/mock"
 
+import "github.com/SUSE/cf-usb/lib/csm/models"
+import "github.com/SUSE/cf-usb/lib/csm"
+
 type CSM struct {
 	mock.Mock
 }
 
-// Login provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *CSM) Login(_a0 string, _a1 string, _a2 string, _a3 bool) error {
-	ret := _m.Called(_a0, _a1, _a2, _a3)
+// CreateWorkspace provides a mock function with given fields: _a0, _a1
+func (_m *CSM) CreateWorkspace(_a0 string, _a1 map[string]interface{}) (*models.ServiceManagerWorkspaceResponse, error) {
+	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, bool) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3)
+	var r0 *models.ServiceManagerWorkspaceResponse
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) *models.ServiceManagerWorkspaceResponse); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ServiceManagerWorkspaceResponse)
+		}
 	}
 
-	return r0
-}
-
-// CreateWorkspace provides a mock function with given fields: _a0
-func (_m *CSM) CreateWorkspace(_a0 string) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(_a0)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, map[string]interface{}) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // WorkspaceExists provides a mock function with given fields: _a0
@@ -62,6 +60,43 @@ func (_m *CSM) WorkspaceExists(_a0 string) (bool, bool, error) {
 	return r0, r1, r2
 }
 
+// GetWorkspace provides a mock function with given fields: _a0
+func (_m *CSM) GetWorkspace(_a0 string) (*models.ServiceManagerWorkspaceResponse, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *models.ServiceManagerWorkspaceResponse
+	if rf, ok := ret.Get(0).(func(string) *models.ServiceManagerWorkspaceResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ServiceManagerWorkspaceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWorkspace provides a mock function with given fields: _a0, _a1
+func (_m *CSM) UpdateWorkspace(_a0 string, _a1 map[string]interface{}) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteWorkspace provides a mock function with given fields: _a0
 func (_m *CSM) DeleteWorkspace(_a0 string) error {
 	ret := _m.Called(_a0)
@@ -76,13 +111,13 @@ func (_m *CSM) DeleteWorkspace(_a0 string) error {
 	return r0
 }
 
-// CreateConnection provides a mock function with given fields: _a0, _a1
-func (_m *CSM) CreateConnection(_a0 string, _a1 string) (interface{}, error) {
-	ret := _m.Called(_a0, _a1)
+// CreateConnection provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CSM) CreateConnection(_a0 string, _a1 string, _a2 map[string]interface{}) (interface{}, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 interface{}
-	if rf, ok := ret.Get(0).(func(string, string) interface{}); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) interface{}); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
@@ -90,8 +125,8 @@ func (_m *CSM) CreateConnection(_a0 string, _a1 string) (interface{}, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(string, string, map[string]interface{}) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -127,6 +162,29 @@ func (_m *CSM) ConnectionExists(_a0 string, _a1 string) (bool, bool, error) {
 	return r0, r1, r2
 }
 
+// GetConnection provides a mock function with given fields: _a0, _a1
+func (_m *CSM) GetConnection(_a0 string, _a1 string) (*models.ServiceManagerConnectionResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *models.ServiceManagerConnectionResponse
+	if rf, ok := ret.Get(0).(func(string, string) *models.ServiceManagerConnectionResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ServiceManagerConnectionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteConnection provides a mock function with given fields: _a0, _a1
 func (_m *CSM) DeleteConnection(_a0 string, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -161,3 +219,58 @@ func (_m *CSM) GetStatus() (string, error) {
 
 	return r0, r1
 }
+
+// GetStatusResponse provides a mock function with given fields:
+func (_m *CSM) GetStatusResponse() (*models.StatusResponse, error) {
+	ret := _m.Called()
+
+	var r0 *models.StatusResponse
+	if rf, ok := ret.Get(0).(func() *models.StatusResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StatusResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithOriginatingIdentity provides a mock function with given fields: _a0
+func (_m *CSM) WithOriginatingIdentity(_a0 string) csm.CSM {
+	ret := _m.Called(_a0)
+
+	var r0 csm.CSM
+	if rf, ok := ret.Get(0).(func(string) csm.CSM); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(csm.CSM)
+		}
+	}
+
+	return r0
+}
+
+// WithRequestID provides a mock function with given fields: _a0
+func (_m *CSM) WithRequestID(_a0 string) csm.CSM {
+	ret := _m.Called(_a0)
+
+	var r0 csm.CSM
+	if rf, ok := ret.Get(0).(func(string) csm.CSM); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(csm.CSM)
+		}
+	}
+
+	return r0
+}
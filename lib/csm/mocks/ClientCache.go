@@ -0,0 +1,36 @@
+package mocks
+
+import "github.com/SUSE/cf-usb/lib/csm"
+import "github.com/stretchr/testify/mock"
+
+type ClientCache struct {
+	mock.Mock
+}
+
+// GetClient provides a mock function with given fields: _a0, _a1
+func (_m *ClientCache) GetClient(_a0 string, _a1 csm.Endpoint) (csm.CSM, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 csm.CSM
+	if rf, ok := ret.Get(0).(func(string, csm.Endpoint) csm.CSM); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(csm.CSM)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, csm.Endpoint) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: _a0
+func (_m *ClientCache) Remove(_a0 string) {
+	_m.Called(_a0)
+}
@@ -0,0 +1,50 @@
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/go-openapi/errors"
+)
+
+/*ServiceManagerWorkspaceUpdateRequest service manager workspace update request
+
+swagger:model ServiceManagerWorkspaceUpdateRequest
+*/
+type ServiceManagerWorkspaceUpdateRequest struct {
+
+	/* key value map with workspace details (Service manager won't interpret the details of the map it will send this back to the requester as is)
+	 */
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Validate validates this service manager workspace update request
+func (m *ServiceManagerWorkspaceUpdateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDetails(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ServiceManagerWorkspaceUpdateRequest) validateDetails(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Details) { // not required
+		return nil
+	}
+
+	if swag.IsZero(m.Details) { // not required
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/validate"
+)
+
+/*DashboardClient Optional OAuth2 client the Cloud Controller creates in the UAA so users can sign on to the
+dashboard of the service.
+
+
+swagger:model dashboardClient
+*/
+type DashboardClient struct {
+
+	/* The id of the OAuth2 client used by the service dashboard.
+
+
+	Required: true
+	*/
+	ID *string `json:"id"`
+
+	/* A domain for the service dashboard that will be whitelisted by the UAA
+	to enable SSO.
+
+
+	Required: true
+	*/
+	RedirectURI *string `json:"redirectURI"`
+
+	/* The secret of the OAuth2 client used by the service dashboard.
+
+
+	Required: true
+	*/
+	Secret *string `json:"secret"`
+}
+
+// Validate validates this dashboard client
+func (m *DashboardClient) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateID(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateRedirectURI(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateSecret(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DashboardClient) validateID(formats strfmt.Registry) error {
+
+	if err := validate.Required("id", "body", m.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DashboardClient) validateRedirectURI(formats strfmt.Registry) error {
+
+	if err := validate.Required("redirectURI", "body", m.RedirectURI); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DashboardClient) validateSecret(formats strfmt.Registry) error {
+
+	if err := validate.Required("secret", "body", m.Secret); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+/*Dial dial
+
+swagger:model dial
+*/
+type Dial struct {
+
+	/* Features of the plan displayed by graphical clients.
+
+	 */
+	Bullets []string `json:"bullets,omitempty"`
+
+	/* Configuration of the plan, sent to the driver endpoint when service
+	instances are created.
+
+	*/
+	Configuration interface{} `json:"configuration,omitempty"`
+
+	/* Costs of the plan displayed by graphical clients.
+
+	 */
+	Costs []*DialCost `json:"costs,omitempty"`
+
+	/* A short description of the plan that will appear in the catalog.
+
+	 */
+	Description string `json:"description,omitempty"`
+
+	/* The name of the plan displayed by graphical clients.
+
+	 */
+	DisplayName string `json:"display_name,omitempty"`
+
+	/* Indicates if the plan is free, non free plans are limited by the quota
+	of the organization.
+
+	*/
+	Free *bool `json:"free,omitempty"`
+
+	/* USB generated ID for the dial.
+
+	 */
+	ID string `json:"id,omitempty"`
+
+	/* The CLI-friendly name of the plan. All lowercase, no spaces.
+
+
+	Required: true
+	*/
+	Name *string `json:"name"`
+
+	/* USB generated ID of the plan of the dial, it's the plan ID sent by the
+	Cloud Controller when provisioning service instances.
+
+	*/
+	PlanID string `json:"plan_id,omitempty"`
+
+	/* Schemas of the parameters accepted by the plan when creating service
+	instances and bindings.
+
+	*/
+	Schemas interface{} `json:"schemas,omitempty"`
+}
+
+// Validate validates this dial
+func (m *Dial) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateBullets(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateCosts(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Dial) validateBullets(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Bullets) { // not required
+		return nil
+	}
+
+	return nil
+}
+
+func (m *Dial) validateCosts(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Costs) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Costs); i++ {
+
+		if swag.IsZero(m.Costs[i]) { // not required
+			continue
+		}
+
+		if m.Costs[i] != nil {
+
+			if err := m.Costs[i].Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Dial) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
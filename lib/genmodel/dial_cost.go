@@ -0,0 +1,37 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+)
+
+/*DialCost dial cost
+
+swagger:model dialCost
+*/
+type DialCost struct {
+
+	/* Amount of the cost keyed by currency, e.g. {"usd": 9.99}
+
+	 */
+	Amount map[string]float64 `json:"amount,omitempty"`
+
+	/* Unit of the cost, e.g. MONTHLY
+
+	 */
+	Unit string `json:"unit,omitempty"`
+}
+
+// Validate validates this dial cost
+func (m *DialCost) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
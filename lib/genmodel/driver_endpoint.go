@@ -7,6 +7,7 @@ import (
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -25,7 +26,11 @@ type DriverEndpoint struct {
 	/* The certificate used to issue the certificate providing TLS
 
 	 */
-	CaCertificate string `json:"caCertificate,omitempty"`
+	CaCertificate *string `json:"caCertificate,omitempty"`
+
+	/* dashboard client
+	 */
+	DashboardClient *DashboardClient `json:"dashboardClient,omitempty"`
 
 	/* URL for the driver endpoint. Used by the USB to create service
 	instances, generate credentials, discover plans and schemas.
@@ -60,6 +65,11 @@ type DriverEndpoint struct {
 func (m *DriverEndpoint) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDashboardClient(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		// prop
 		res = append(res, err)
@@ -71,6 +81,22 @@ func (m *DriverEndpoint) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DriverEndpoint) validateDashboardClient(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DashboardClient) { // not required
+		return nil
+	}
+
+	if m.DashboardClient != nil {
+
+		if err := m.DashboardClient.Validate(formats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DriverEndpoint) validateName(formats strfmt.Registry) error {
 
 	if err := validate.Required("name", "body", m.Name); err != nil {
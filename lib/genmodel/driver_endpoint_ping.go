@@ -0,0 +1,156 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+/*DriverEndpointPing driver endpoint ping
+
+swagger:model driverEndpointPing
+*/
+type DriverEndpointPing struct {
+
+	/* Diagnostics performed by the CSM to determine its status.
+
+	 */
+	Diagnostics []*DriverEndpointPingDiagnostic `json:"diagnostics,omitempty"`
+
+	/* The error of the status request.
+
+	 */
+	Error string `json:"error,omitempty"`
+
+	/* Kind of failure when the status request failed: the CSM could not be
+	reached, its certificate was not accepted, it rejected the authentication
+	key or it answered with another error.
+
+	*/
+	ErrorType string `json:"error_type,omitempty"`
+
+	/* Time taken by the status request, in milliseconds.
+
+	 */
+	LatencyMs int64 `json:"latency_ms,omitempty"`
+
+	/* Message reported by the CSM along with its status.
+
+	 */
+	Message string `json:"message,omitempty"`
+
+	/* Whether the CSM of the driver endpoint answered the status request.
+
+
+	Required: true
+	*/
+	Reachable *bool `json:"reachable"`
+
+	/* Type of the service provided by the driver endpoint.
+
+	 */
+	ServiceType string `json:"service_type,omitempty"`
+
+	/* Status reported by the CSM, successful when it can perform all functions.
+
+	 */
+	Status string `json:"status,omitempty"`
+}
+
+// Validate validates this driver endpoint ping
+func (m *DriverEndpointPing) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDiagnostics(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateErrorType(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateReachable(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DriverEndpointPing) validateDiagnostics(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Diagnostics) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Diagnostics); i++ {
+
+		if swag.IsZero(m.Diagnostics[i]) { // not required
+			continue
+		}
+
+		if m.Diagnostics[i] != nil {
+
+			if err := m.Diagnostics[i].Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var driverEndpointPingTypeErrorTypePropEnum []interface{}
+
+// prop value enum
+func (m *DriverEndpointPing) validateErrorTypeEnum(path, location string, value string) error {
+	if driverEndpointPingTypeErrorTypePropEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["connection","tls","authentication","csm"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			driverEndpointPingTypeErrorTypePropEnum = append(driverEndpointPingTypeErrorTypePropEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, driverEndpointPingTypeErrorTypePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *DriverEndpointPing) validateErrorType(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ErrorType) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateErrorTypeEnum("error_type", "body", m.ErrorType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DriverEndpointPing) validateReachable(formats strfmt.Registry) error {
+
+	if err := validate.Required("reachable", "body", m.Reachable); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+)
+
+/*DriverEndpointPingDiagnostic driver endpoint ping diagnostic
+
+swagger:model driverEndpointPingDiagnostic
+*/
+type DriverEndpointPingDiagnostic struct {
+
+	/* Full description of the diagnostic.
+
+	 */
+	Description string `json:"description,omitempty"`
+
+	/* Detail that informs the success or failure of the diagnostic.
+
+	 */
+	Message string `json:"message,omitempty"`
+
+	/* Name of the diagnostic.
+
+	 */
+	Name string `json:"name,omitempty"`
+
+	/* Status of the diagnostic.
+
+	 */
+	Status string `json:"status,omitempty"`
+}
+
+// Validate validates this driver endpoint ping diagnostic
+func (m *DriverEndpointPingDiagnostic) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
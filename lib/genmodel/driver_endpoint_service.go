@@ -0,0 +1,138 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+/*DriverEndpointService driver endpoint service
+
+swagger:model driverEndpointService
+*/
+type DriverEndpointService struct {
+
+	/* Indicates if service instances of the service can be bound to applications.
+
+	 */
+	Bindable *bool `json:"bindable,omitempty"`
+
+	/* A short description of the service that will appear in the catalog.
+
+	 */
+	Description string `json:"description,omitempty"`
+
+	/* The name of the service displayed by graphical clients.
+
+	 */
+	DisplayName string `json:"display_name,omitempty"`
+
+	/* URL of the documentation of the service.
+
+	 */
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
+	/* USB generated ID for the service.
+
+	 */
+	ID string `json:"id,omitempty"`
+
+	/* URL of the image displayed by graphical clients for the service.
+
+	 */
+	ImageURL string `json:"image_url,omitempty"`
+
+	/* The name of the service, it's the name of the driver endpoint and can't
+	be changed.
+
+	*/
+	Name string `json:"name,omitempty"`
+
+	/* Indicates if service instances of the service can be updated to another plan.
+
+	 */
+	PlanUpdateable *bool `json:"plan_updateable,omitempty"`
+
+	/* Permissions the user must grant for service instances of the service to work.
+
+	 */
+	Requires []string `json:"requires,omitempty"`
+
+	/* Tags of the service, provided to the applications bound to its instances.
+
+	 */
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Validate validates this driver endpoint service
+func (m *DriverEndpointService) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRequires(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if err := m.validateTags(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var driverEndpointServiceRequiresItemsEnum []interface{}
+
+func (m *DriverEndpointService) validateRequiresItemsEnum(path, location string, value string) error {
+	if driverEndpointServiceRequiresItemsEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["route_forwarding","syslog_drain","volume_mount"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			driverEndpointServiceRequiresItemsEnum = append(driverEndpointServiceRequiresItemsEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, driverEndpointServiceRequiresItemsEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *DriverEndpointService) validateRequires(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Requires) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Requires); i++ {
+
+		// value enum
+		if err := m.validateRequiresItemsEnum("requires"+"."+strconv.Itoa(i), "body", m.Requires[i]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DriverEndpointService) validateTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Tags) { // not required
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+/*RegistrationError registration error
+
+swagger:model registrationError
+*/
+type RegistrationError struct {
+
+	/* The error that made the registration fail.
+
+	 */
+	Message string `json:"message,omitempty"`
+
+	/* The steps of the registration that were attempted, in the order they were
+	performed, with the result of undoing them.
+
+	*/
+	Steps []*RegistrationStep `json:"steps,omitempty"`
+}
+
+// Validate validates this registration error
+func (m *RegistrationError) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateSteps(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *RegistrationError) validateSteps(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Steps) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Steps); i++ {
+
+		if swag.IsZero(m.Steps[i]) { // not required
+			continue
+		}
+
+		if m.Steps[i] != nil {
+
+			if err := m.Steps[i].Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
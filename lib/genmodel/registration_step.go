@@ -0,0 +1,85 @@
+package genmodel
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+/*RegistrationStep registration step
+
+swagger:model registrationStep
+*/
+type RegistrationStep struct {
+
+	/* The error of the step or of undoing it.
+
+	 */
+	Error string `json:"error,omitempty"`
+
+	/* Name of the step.
+
+	 */
+	Name string `json:"name,omitempty"`
+
+	/* State the step was left in: it succeeded and did not need to be undone,
+	it failed, it was undone or undoing it failed.
+
+	*/
+	Status string `json:"status,omitempty"`
+}
+
+// Validate validates this registration step
+func (m *RegistrationStep) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateStatus(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var registrationStepTypeStatusPropEnum []interface{}
+
+// prop value enum
+func (m *RegistrationStep) validateStatusEnum(path, location string, value string) error {
+	if registrationStepTypeStatusPropEnum == nil {
+		var res []string
+		if err := json.Unmarshal([]byte(`["succeeded","failed","rolled_back","rollback_failed"]`), &res); err != nil {
+			return err
+		}
+		for _, v := range res {
+			registrationStepTypeStatusPropEnum = append(registrationStepTypeStatusPropEnum, v)
+		}
+	}
+	if err := validate.Enum(path, location, value, registrationStepTypeStatusPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RegistrationStep) validateStatus(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
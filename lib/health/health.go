@@ -0,0 +1,179 @@
+//Package health serves the liveness and readiness endpoints of the USB
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+//CheckTimeout is how long a readiness check may take before it is reported as failed
+var CheckTimeout = 5 * time.Second
+
+//Check returns an error when the dependency it checks is not usable
+type Check func() error
+
+//CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+//Report is the body of the readiness response
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+//Statuses reported by the health endpoints
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusReady    = "ready"
+	StatusNotReady = "not ready"
+)
+
+type namedCheck struct {
+	name     string
+	check    Check
+	optional bool
+}
+
+//Checker holds the checks run by the readiness endpoint
+type Checker struct {
+	sync.Mutex
+	checks   []namedCheck
+	optional func() map[string]Check
+}
+
+//NewChecker creates a Checker without checks
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+//AddCheck adds a check the USB must pass to be ready
+func (checker *Checker) AddCheck(name string, check Check) {
+	checker.Lock()
+	defer checker.Unlock()
+	checker.checks = append(checker.checks, namedCheck{name: name, check: check})
+}
+
+//SetOptionalChecks sets the function listing the checks that are only run when asked for; their failures
+//are reported without making the USB unready
+func (checker *Checker) SetOptionalChecks(optional func() map[string]Check) {
+	checker.Lock()
+	defer checker.Unlock()
+	checker.optional = optional
+}
+
+//Ready runs the checks, the optional ones as well when withOptional is set, and reports their results
+func (checker *Checker) Ready(withOptional bool) Report {
+	checker.Lock()
+	checks := append([]namedCheck{}, checker.checks...)
+	optional := checker.optional
+	checker.Unlock()
+
+	if withOptional && optional != nil {
+		optionalChecks := optional()
+		names := []string{}
+		for name := range optionalChecks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			checks = append(checks, namedCheck{name: name, check: optionalChecks[name], optional: true})
+		}
+	}
+
+	results := make([]CheckResult, len(checks))
+	var wait sync.WaitGroup
+	for i, check := range checks {
+		wait.Add(1)
+		go func(i int, check namedCheck) {
+			defer wait.Done()
+			results[i] = runCheck(check)
+		}(i, check)
+	}
+	wait.Wait()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]CheckResult)}
+	for i, check := range checks {
+		report.Checks[check.name] = results[i]
+		if results[i].Status != StatusOK && !check.optional {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
+
+func runCheck(check namedCheck) CheckResult {
+	result := CheckResult{Status: StatusOK, Optional: check.optional}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check.check()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err.Error()
+		}
+	case <-time.After(CheckTimeout):
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("check did not complete within %s", CheckTimeout)
+	}
+
+	return result
+}
+
+//Handler serves /healthz, which succeeds as long as the USB is serving requests, and /readyz, which
+//succeeds when the checks pass. With withOptional set, optional checks are run when /readyz is called with
+//?optional=true
+func (checker *Checker) Handler(withOptional bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Ready(withOptional && r.URL.Query().Get("optional") == "true")
+		status := http.StatusOK
+		if report.Status != StatusReady {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+	return mux
+}
+
+//Wrap serves the health endpoints on the listener of handler, including the optional checks
+func (checker *Checker) Wrap(handler http.Handler) http.Handler {
+	return wrap(checker.Handler(true), handler)
+}
+
+//WrapPublic serves the health endpoints on a publicly routed listener of handler. The optional checks are not served
+//there since any client could make them call every sidecar
+func (checker *Checker) WrapPublic(handler http.Handler) http.Handler {
+	return wrap(checker.Handler(false), handler)
+}
+
+func wrap(health http.Handler, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			health.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness(t *testing.T) {
+	assert := assert.New(t)
+
+	checker := NewChecker()
+	checker.AddCheck("config_provider", func() error {
+		return nil
+	})
+	checker.SetOptionalChecks(func() map[string]Check {
+		return map[string]Check{"driver_endpoint:mysql": func() error {
+			return errors.New("unreachable")
+		}}
+	})
+	handler := checker.Wrap(http.NotFoundHandler())
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/readyz?optional=true", nil))
+	assert.Equal(http.StatusOK, response.Code)
+
+	report := Report{}
+	assert.NoError(json.Unmarshal(response.Body.Bytes(), &report))
+	assert.Equal(StatusReady, report.Status)
+	assert.Equal(CheckResult{Status: StatusOK}, report.Checks["config_provider"])
+	assert.Equal(CheckResult{Status: StatusFailed, Error: "unreachable", Optional: true}, report.Checks["driver_endpoint:mysql"])
+
+	checker.AddCheck("uaa_token_endpoint", func() error {
+		return errors.New("not resolved")
+	})
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(http.StatusServiceUnavailable, response.Code)
+
+	report = Report{}
+	assert.NoError(json.Unmarshal(response.Body.Bytes(), &report))
+	assert.Equal(StatusNotReady, report.Status)
+	assert.Len(report.Checks, 2)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/v2/catalog", nil))
+	assert.Equal(http.StatusNotFound, response.Code)
+}
+
+func TestPublicReadiness(t *testing.T) {
+	assert := assert.New(t)
+
+	checker := NewChecker()
+	checker.AddCheck("config_provider", func() error {
+		return nil
+	})
+	checker.SetOptionalChecks(func() map[string]Check {
+		t.Error("optional checks run on the public listener")
+		return nil
+	})
+	handler := checker.WrapPublic(http.NotFoundHandler())
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/readyz?optional=true", nil))
+	assert.Equal(http.StatusOK, response.Code)
+
+	report := Report{}
+	assert.NoError(json.Unmarshal(response.Body.Bytes(), &report))
+	assert.Len(report.Checks, 1)
+}
@@ -0,0 +1,144 @@
+//Package httpmiddleware holds the HTTP middleware shared by the broker and the management API
+package httpmiddleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/metrics"
+	"github.com/pivotal-golang/lager"
+	uuid "github.com/satori/go.uuid"
+)
+
+type requestIDKey struct{}
+
+//validRequestID limits the request ids accepted from clients to ones that are safe to log and forward
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+//RequestID returns the id of the request, or an empty string if the request did not go through RequestIDs
+func RequestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	requestID, _ := r.Context().Value(requestIDKey{}).(string)
+	return requestID
+}
+
+//RequestIDs gives every request an id, the one sent by the client if it is valid or a new one otherwise.
+//The id is recorded in the request context and returned in the response headers
+func RequestIDs(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(csm.RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewV4().String()
+		}
+
+		w.Header().Set(csm.RequestIDHeader, requestID)
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID)))
+	})
+}
+
+//statusRecorder remembers the status and size of the response written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	size, err := w.ResponseWriter.Write(data)
+	w.size += size
+	return size, err
+}
+
+//AccessLog logs every request once it has been served, with its status and latency
+func AccessLog(logger lager.Logger, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		handler.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		logger.Info("request-served", lager.Data{
+			"request-id":  RequestID(r),
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"remote-addr": r.RemoteAddr,
+			"status":      recorder.status,
+			"size":        recorder.size,
+			"duration-ms": float64(time.Since(start).Nanoseconds()) / float64(time.Millisecond),
+		})
+	})
+}
+
+//Metrics records the status and latency of every request served by api, labelled with the template of its route
+func Metrics(api string, templates *metrics.PathTemplates, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder, ok := w.(*statusRecorder)
+		if !ok {
+			recorder = &statusRecorder{ResponseWriter: w}
+		}
+
+		defer func() {
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			metrics.ObserveHTTPRequest(api, r.Method, templates.Template(r.URL.Path), status, time.Since(start))
+		}()
+
+		handler.ServeHTTP(recorder, r)
+	})
+}
+
+//Recovery turns a panic in handler into a 500 response written by writeError, instead of a dropped connection
+func Recovery(logger lager.Logger, handler http.Handler, writeError func(w http.ResponseWriter, message string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder, ok := w.(*statusRecorder)
+		if !ok {
+			recorder = &statusRecorder{ResponseWriter: w}
+		}
+
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			err := fmt.Errorf("%v", recovered)
+			logger.Error("request-panicked", err, lager.Data{
+				"request-id": RequestID(r),
+				"method":     r.Method,
+				"path":       r.URL.Path,
+				"stack":      string(debug.Stack()),
+			})
+
+			// Once the handler started the response the status can not be changed any more
+			if recorder.status == 0 {
+				writeError(recorder, fmt.Sprintf("Internal error handling request %s", RequestID(r)))
+			}
+		}()
+
+		handler.ServeHTTP(recorder, r)
+	})
+}
@@ -0,0 +1,60 @@
+package httpmiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	var seen string
+	handler := RequestIDs(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r)
+	}))
+
+	request := httptest.NewRequest("GET", "/v2/catalog", nil)
+	request.Header.Set(csm.RequestIDHeader, "from-platform")
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal("from-platform", seen)
+	assert.Equal("from-platform", response.Header().Get(csm.RequestIDHeader))
+
+	request = httptest.NewRequest("GET", "/v2/catalog", nil)
+	request.Header.Set(csm.RequestIDHeader, "not valid\n")
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.NotEqual("not valid\n", seen)
+	assert.Len(seen, 36)
+	assert.Equal(seen, response.Header().Get(csm.RequestIDHeader))
+}
+
+func TestRecoveryAndAccessLog(t *testing.T) {
+	assert := assert.New(t)
+	logger := lagertest.NewTestLogger("httpmiddleware-test")
+
+	var written string
+	handler := RequestIDs(AccessLog(logger, Recovery(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler failed")
+	}), func(w http.ResponseWriter, message string) {
+		written = message
+		w.WriteHeader(http.StatusInternalServerError)
+	})))
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("PUT", "/v2/service_instances/instance", nil))
+
+	assert.Equal(http.StatusInternalServerError, response.Code)
+	assert.Contains(written, response.Header().Get(csm.RequestIDHeader))
+
+	logs := logger.Logs()
+	assert.Len(logs, 2)
+	assert.Equal("httpmiddleware-test.request-panicked", logs[0].Message)
+	assert.Equal("httpmiddleware-test.request-served", logs[1].Message)
+	assert.EqualValues(http.StatusInternalServerError, logs[1].Data["status"])
+}
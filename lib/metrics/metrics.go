@@ -0,0 +1,242 @@
+//Package metrics keeps the counters and latency histograms of the USB and serves them in the Prometheus text format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//DefaultBuckets are the upper bounds, in seconds, of the latency histograms
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+//Registry holds the metrics served by its handler
+type Registry struct {
+	sync.Mutex
+	collectors []collector
+}
+
+//NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (registry *Registry) register(c collector) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.collectors = append(registry.collectors, c)
+}
+
+//Handler serves the metrics of the registry in the Prometheus text format
+func (registry *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registry.Lock()
+		collectors := append([]collector{}, registry.collectors...)
+		registry.Unlock()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writer := bufio.NewWriter(w)
+		for _, c := range collectors {
+			c.write(writer)
+		}
+		writer.Flush()
+	})
+}
+
+//metric holds what counters and histograms have in common, series are keyed by their label values
+type metric struct {
+	sync.Mutex
+	name   string
+	help   string
+	labels []string
+}
+
+func (m *metric) key(values []string) string {
+	if len(values) != len(m.labels) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", m.name, len(m.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (m *metric) writeHeader(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", m.name, strings.Replace(m.help, "\n", " ", -1))
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.name, kind)
+}
+
+func (m *metric) labelPairs(values []string, extra ...string) string {
+	pairs := []string{}
+	for i, label := range m.labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escapeLabelValue(values[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[i], escapeLabelValue(extra[i+1])))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return strings.Replace(value, "\n", `\n`, -1)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	metric
+	values map[string][]string
+	counts map[string]float64
+}
+
+//NewCounterVec creates a CounterVec and registers it with registry
+func NewCounterVec(registry *Registry, name string, help string, labels ...string) *CounterVec {
+	counter := &CounterVec{
+		metric: metric{name: name, help: help, labels: labels},
+		values: make(map[string][]string),
+		counts: make(map[string]float64),
+	}
+	registry.register(counter)
+	return counter
+}
+
+//Inc adds one to the counter of labelValues
+func (counter *CounterVec) Inc(labelValues ...string) {
+	key := counter.key(labelValues)
+
+	counter.Lock()
+	defer counter.Unlock()
+	counter.values[key] = labelValues
+	counter.counts[key]++
+}
+
+//Value returns the count of labelValues
+func (counter *CounterVec) Value(labelValues ...string) float64 {
+	key := counter.key(labelValues)
+
+	counter.Lock()
+	defer counter.Unlock()
+	return counter.counts[key]
+}
+
+func (counter *CounterVec) write(w *bufio.Writer) {
+	counter.Lock()
+	defer counter.Unlock()
+
+	counter.writeHeader(w, "counter")
+	for _, key := range sortedKeys(counter.values) {
+		fmt.Fprintf(w, "%s%s %s\n", counter.name, counter.labelPairs(counter.values[key]), formatFloat(counter.counts[key]))
+	}
+}
+
+type histogramSeries struct {
+	values  []string
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+//HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	metric
+	bounds []float64
+	series map[string]*histogramSeries
+}
+
+//NewHistogramVec creates a HistogramVec with the given bucket upper bounds and registers it with registry
+func NewHistogramVec(registry *Registry, name string, help string, bounds []float64, labels ...string) *HistogramVec {
+	sorted := append([]float64{}, bounds...)
+	sort.Float64s(sorted)
+
+	histogram := &HistogramVec{
+		metric: metric{name: name, help: help, labels: labels},
+		bounds: sorted,
+		series: make(map[string]*histogramSeries),
+	}
+	registry.register(histogram)
+	return histogram
+}
+
+//Observe records value in the histogram of labelValues
+func (histogram *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := histogram.key(labelValues)
+
+	histogram.Lock()
+	defer histogram.Unlock()
+
+	series, ok := histogram.series[key]
+	if !ok {
+		series = &histogramSeries{values: labelValues, buckets: make([]uint64, len(histogram.bounds))}
+		histogram.series[key] = series
+	}
+
+	for i, bound := range histogram.bounds {
+		if value <= bound {
+			series.buckets[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+//Count returns the number of values observed for labelValues
+func (histogram *HistogramVec) Count(labelValues ...string) uint64 {
+	key := histogram.key(labelValues)
+
+	histogram.Lock()
+	defer histogram.Unlock()
+
+	series, ok := histogram.series[key]
+	if !ok {
+		return 0
+	}
+	return series.count
+}
+
+func (histogram *HistogramVec) write(w *bufio.Writer) {
+	histogram.Lock()
+	defer histogram.Unlock()
+
+	keys := []string{}
+	for key := range histogram.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	histogram.writeHeader(w, "histogram")
+	for _, key := range keys {
+		series := histogram.series[key]
+		for i, bound := range histogram.bounds {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", histogram.name, histogram.labelPairs(series.values, "le", formatFloat(bound)), series.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", histogram.name, histogram.labelPairs(series.values, "le", "+Inf"), series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", histogram.name, histogram.labelPairs(series.values), formatFloat(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", histogram.name, histogram.labelPairs(series.values), series.count)
+	}
+}
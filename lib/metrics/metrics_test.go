@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewRegistry()
+	counter := NewCounterVec(registry, "test_requests_total", "Requests", "path")
+	histogram := NewHistogramVec(registry, "test_duration_seconds", "Durations", []float64{1, 0.1}, "path")
+
+	counter.Inc(`/a"b`)
+	counter.Inc(`/a"b`)
+	histogram.Observe(0.05, "/x")
+	histogram.Observe(0.5, "/x")
+	histogram.Observe(5, "/x")
+
+	response := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(`# HELP test_requests_total Requests
+# TYPE test_requests_total counter
+test_requests_total{path="/a\"b"} 2
+# HELP test_duration_seconds Durations
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{path="/x",le="0.1"} 1
+test_duration_seconds_bucket{path="/x",le="1"} 2
+test_duration_seconds_bucket{path="/x",le="+Inf"} 3
+test_duration_seconds_sum{path="/x"} 5.55
+test_duration_seconds_count{path="/x"} 3
+`, response.Body.String())
+	assert.Contains(response.Header().Get("Content-Type"), "version=0.0.4")
+}
+
+func TestPathTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	templates, err := SpecPathTemplates([]byte(`{
+		"basePath": "/v2",
+		"paths": {
+			"/catalog": {},
+			"/service_instances/{instance_id}": {},
+			"/service_instances/{instance_id}/last_operation": {},
+			"/service_instances/{instance_id}/service_bindings/{binding_id}": {},
+			"/service_instances/{instance_id}/service_bindings/{binding_id}/last_operation": {}
+		}
+	}`))
+	assert.NoError(err)
+
+	assert.Equal("/v2/catalog", templates.Template("/v2/catalog"))
+	assert.Equal("/v2/catalog", templates.Template("/v2/catalog/"))
+	assert.Equal("/v2/swagger.json", templates.Template("/v2/swagger.json"))
+	assert.Equal("/v2/service_instances/{instance_id}", templates.Template("/v2/service_instances/my-instance"))
+	assert.Equal("/v2/service_instances/{instance_id}/last_operation",
+		templates.Template("/v2/service_instances/my-instance/last_operation"))
+	assert.Equal("/v2/service_instances/{instance_id}/service_bindings/{binding_id}/last_operation",
+		templates.Template("/v2/service_instances/i1/service_bindings/b1/last_operation?operation=x"))
+	assert.Equal("other", templates.Template("/v2/service_instances"))
+	assert.Equal("other", templates.Template("/v2/service_instances/i1/other"))
+	assert.Equal("other", templates.Template("/"))
+	assert.Equal("other", templates.Template("/wp-admin/login.php"))
+
+	templates = NewPathTemplates("/driver_endpoints/{driver_endpoint_id}", "/driver_endpoints/{driver_endpoint_id}/dials/{dial_id}",
+		"/driver_endpoints/default")
+	assert.Equal("/driver_endpoints/default", templates.Template("/driver_endpoints/default"))
+	assert.Equal("/driver_endpoints/{driver_endpoint_id}/dials/{dial_id}", templates.Template("/driver_endpoints/abc/dials/def"))
+	assert.Equal("/driver_endpoints/{driver_endpoint_id}", templates.Template("/driver_endpoints/abc"))
+
+	var missing *PathTemplates
+	assert.Equal("other", missing.Template("/driver_endpoints/abc"))
+
+	_, err = SpecPathTemplates([]byte(`{"paths": []}`))
+	assert.Error(err)
+}
+
+func TestClientPathTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("/v2/service_plans/{guid}", clientPathTemplate("/v2/service_plans/0c5b1c7e-0e8b-4fd8-9a0b-2d6f0b3f7a11"))
+	assert.Equal("/v2/service_plans/{guid}/service_instances",
+		clientPathTemplate("/v2/service_plans/0c5b1c7e-0e8b-4fd8-9a0b-2d6f0b3f7a11/service_instances"))
+	assert.Equal("/v2/service_brokers", clientPathTemplate("/v2/service_brokers?q=name:usb"))
+	assert.Equal("/oauth/token", clientPathTemplate("/oauth/token"))
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+//otherPath is the template of the paths that are not routes of an API
+const otherPath = "other"
+
+//PathTemplates maps the paths of the requests served by an API to the templates of its routes
+type PathTemplates struct {
+	routes [][]string
+}
+
+//NewPathTemplates creates PathTemplates for routes such as "/v2/service_instances/{instance_id}"
+func NewPathTemplates(routes ...string) *PathTemplates {
+	templates := &PathTemplates{}
+	for _, route := range routes {
+		templates.routes = append(templates.routes, pathSegments(route))
+	}
+	return templates
+}
+
+//SpecPathTemplates creates PathTemplates for the routes of the API described by a swagger spec, including the
+//route serving the spec itself
+func SpecPathTemplates(spec json.RawMessage) (*PathTemplates, error) {
+	var document struct {
+		BasePath string                     `json:"basePath"`
+		Paths    map[string]json.RawMessage `json:"paths"`
+	}
+	err := json.Unmarshal(spec, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := strings.TrimSuffix(document.BasePath, "/")
+	routes := []string{basePath + "/swagger.json"}
+	for path := range document.Paths {
+		routes = append(routes, basePath+path)
+	}
+
+	return NewPathTemplates(routes...), nil
+}
+
+//Template returns the template of the route serving path, so that requests for different resources share their
+//metrics. Paths that are not routes of the API are all reported as "other"
+func (templates *PathTemplates) Template(path string) string {
+	if templates == nil {
+		return otherPath
+	}
+
+	segments := pathSegments(path)
+	var match []string
+	matchStatic := -1
+	for _, route := range templates.routes {
+		static, ok := matchRoute(route, segments)
+		if ok && static > matchStatic {
+			match, matchStatic = route, static
+		}
+	}
+
+	if match == nil {
+		return otherPath
+	}
+	return "/" + strings.Join(match, "/")
+}
+
+//matchRoute checks if the segments of a path match those of route, static counts the segments matched literally
+func matchRoute(route []string, segments []string) (static int, ok bool) {
+	if len(route) != len(segments) {
+		return 0, false
+	}
+
+	for i, segment := range route {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			if segments[i] == "" {
+				return 0, false
+			}
+		case segment == segments[i]:
+			static++
+		default:
+			return 0, false
+		}
+	}
+
+	return static, true
+}
+
+//pathSegments splits path, without its query and fragment, on slashes
+func pathSegments(path string) []string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+//guidSegment matches the guids the cloud controller uses in its paths
+var guidSegment = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+//clientPathTemplate replaces the guids in the path of a call made by the USB, the paths are built by the USB so
+//only the guids of the resources vary
+func clientPathTemplate(path string) string {
+	segments := pathSegments(path)
+	for i, segment := range segments {
+		if guidSegment.MatchString(segment) {
+			segments[i] = "{guid}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+//Default is the registry holding the metrics of the USB
+var Default = NewRegistry()
+
+var (
+	httpRequests = NewCounterVec(Default, "usb_http_requests_total",
+		"Requests served by the USB APIs", "api", "method", "path", "code")
+	httpRequestDuration = NewHistogramVec(Default, "usb_http_request_duration_seconds",
+		"Time taken to serve the requests of the USB APIs", DefaultBuckets, "api", "method", "path", "code")
+
+	csmRequestDuration = NewHistogramVec(Default, "usb_csm_request_duration_seconds",
+		"Time taken by the calls to the CSM of a driver endpoint", DefaultBuckets, "driver_endpoint", "operation")
+	csmRequestErrors = NewCounterVec(Default, "usb_csm_request_errors_total",
+		"Calls to the CSM of a driver endpoint that failed", "driver_endpoint", "operation")
+
+	clientRequestDuration = NewHistogramVec(Default, "usb_client_request_duration_seconds",
+		"Time taken by the calls to the cloud controller and UAA", DefaultBuckets, "host", "method", "path", "code")
+	clientRequestErrors = NewCounterVec(Default, "usb_client_request_errors_total",
+		"Calls to the cloud controller and UAA that failed", "host", "method", "path")
+
+	configProviderDuration = NewHistogramVec(Default, "usb_config_provider_duration_seconds",
+		"Time taken by the calls to the config provider", DefaultBuckets, "operation")
+	configProviderErrors = NewCounterVec(Default, "usb_config_provider_errors_total",
+		"Calls to the config provider that failed", "operation")
+)
+
+//ObserveHTTPRequest records a request served by api, the broker or the management API, template is the route
+//template of the request as returned by PathTemplates
+func ObserveHTTPRequest(api string, method string, template string, code int, duration time.Duration) {
+	status := strconv.Itoa(code)
+	httpRequests.Inc(api, method, template, status)
+	httpRequestDuration.Observe(duration.Seconds(), api, method, template, status)
+}
+
+//ObserveCSMRequest records a call made to the CSM of the driver endpoint named driverEndpoint
+func ObserveCSMRequest(driverEndpoint string, operation string, duration time.Duration, err error) {
+	csmRequestDuration.Observe(duration.Seconds(), driverEndpoint, operation)
+	if err != nil {
+		csmRequestErrors.Inc(driverEndpoint, operation)
+	}
+}
+
+//ObserveClientRequest records a call made to the cloud controller or UAA, code is 0 when no response was received
+func ObserveClientRequest(endpoint string, method string, path string, code int, duration time.Duration, err error) {
+	host := endpoint
+	parsed, parseErr := url.Parse(endpoint)
+	if parseErr == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	template := clientPathTemplate(path)
+
+	status := "none"
+	if code != 0 {
+		status = strconv.Itoa(code)
+	}
+	clientRequestDuration.Observe(duration.Seconds(), host, method, template, status)
+	if err != nil {
+		clientRequestErrors.Inc(host, method, template)
+	}
+}
+
+//ObserveConfigProvider records a call made to the config provider
+func ObserveConfigProvider(operation string, duration time.Duration, err error) {
+	configProviderDuration.Observe(duration.Seconds(), operation)
+	if err != nil {
+		configProviderErrors.Inc(operation)
+	}
+}
@@ -0,0 +1,114 @@
+package mgmt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/SUSE/cf-usb/lib/broker"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
+	"github.com/pivotal-golang/lager"
+)
+
+//brokerPasswordLength is the number of random bytes in a generated broker password
+const brokerPasswordLength = 32
+
+func newBrokerPassword() (string, error) {
+	password := make([]byte, brokerPasswordLength)
+	_, err := rand.Read(password)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(password), nil
+}
+
+//setBrokerAccounts saves accounts and has the broker forget the credentials it accepted with the previous ones
+func setBrokerAccounts(configProvider config.Provider, accounts []config.BrokerAccount) error {
+	err := configProvider.SetBrokerAccounts(accounts)
+	if err != nil {
+		return err
+	}
+	broker.ForgetCredentials()
+	return nil
+}
+
+//rotateBrokerPassword gives the account of credentials.Username a new password and hands it to register.
+//The broker accepts the new password before register is called and keeps accepting the previous ones until
+//register succeeded, so the cloud controller can always connect to the broker
+func rotateBrokerPassword(log lager.Logger, configProvider config.Provider, credentials config.BrokerCredentials, register func(password string) error) error {
+	accounts, err := configProvider.GetBrokerAccounts()
+	if err != nil {
+		return err
+	}
+
+	password, err := newBrokerPassword()
+	if err != nil {
+		return err
+	}
+	hash, err := config.HashBrokerPassword(password)
+	if err != nil {
+		return err
+	}
+	rotated := config.BrokerAccount{Username: credentials.Username, PasswordHash: hash}
+
+	accountExists := false
+	for _, account := range accounts {
+		if account.Username == credentials.Username {
+			accountExists = true
+		}
+	}
+
+	transition := append([]config.BrokerAccount{}, accounts...)
+	if !accountExists && credentials.Password != "" {
+		// The clear text password of the configuration stops being accepted once the account exists
+		configuredHash, err := config.HashBrokerPassword(credentials.Password)
+		if err != nil {
+			return err
+		}
+		transition = append(transition, config.BrokerAccount{Username: credentials.Username, PasswordHash: configuredHash})
+	}
+	transition = append(transition, rotated)
+
+	err = setBrokerAccounts(configProvider, transition)
+	if err != nil {
+		return err
+	}
+
+	err = register(password)
+	if err != nil {
+		rollbackErr := setBrokerAccounts(configProvider, accounts)
+		if rollbackErr != nil {
+			log.Error("rollback-broker-accounts-failed", rollbackErr)
+		}
+		return err
+	}
+
+	remaining := []config.BrokerAccount{}
+	for _, account := range accounts {
+		if account.Username != credentials.Username {
+			remaining = append(remaining, account)
+		}
+	}
+	remaining = append(remaining, rotated)
+
+	return setBrokerAccounts(configProvider, remaining)
+}
+
+//createServiceBroker registers the broker with the cloud controller. The password of the configuration is used as long
+//as the broker accepts it, once it has been rotated away the broker is registered with a new password
+func createServiceBroker(log lager.Logger, configProvider config.Provider, ccServiceBroker ccapi.USBServiceBroker, brokerName ccapi.BrokerName, brokerAPI config.BrokerAPI) error {
+	credentials := brokerAPI.Credentials
+
+	accounts, err := configProvider.GetBrokerAccounts()
+	if err != nil {
+		return err
+	}
+
+	if config.AuthenticateBroker(accounts, credentials, credentials.Username, credentials.Password) {
+		return ccServiceBroker.Create(brokerName, brokerAPI.ExternalURL, credentials.Username, credentials.Password)
+	}
+
+	return rotateBrokerPassword(log, configProvider, credentials, func(password string) error {
+		return ccServiceBroker.Create(brokerName, brokerAPI.ExternalURL, credentials.Username, password)
+	})
+}
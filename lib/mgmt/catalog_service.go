@@ -0,0 +1,69 @@
+package mgmt
+
+import (
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+//Keys of the catalog service metadata displayed by graphical clients
+const (
+	serviceDisplayNameKey      = "displayName"
+	serviceImageURLKey         = "imageUrl"
+	serviceDocumentationURLKey = "documentationUrl"
+)
+
+//endpointService converts the service published in the broker catalog to the one of a driver endpoint
+func endpointService(service brokermodel.CatalogService) *genmodel.DriverEndpointService {
+	bindable := service.Bindable
+	planUpdateable := service.PlanUpdateable
+
+	return &genmodel.DriverEndpointService{
+		ID:               service.ID,
+		Name:             service.Name,
+		Description:      service.Description,
+		Tags:             service.Tags,
+		Bindable:         &bindable,
+		PlanUpdateable:   &planUpdateable,
+		Requires:         service.Requires,
+		DisplayName:      service.Metadata[serviceDisplayNameKey],
+		ImageURL:         service.Metadata[serviceImageURLKey],
+		DocumentationURL: service.Metadata[serviceDocumentationURLKey],
+	}
+}
+
+//updateCatalogService returns service with the fields set in update applied, the name and id of a service
+//can not be changed
+func updateCatalogService(service brokermodel.CatalogService, update *genmodel.DriverEndpointService) brokermodel.CatalogService {
+	if update.Description != "" {
+		service.Description = update.Description
+	}
+	if update.Tags != nil {
+		service.Tags = update.Tags
+	}
+	if update.Bindable != nil {
+		service.Bindable = *update.Bindable
+	}
+	if update.PlanUpdateable != nil {
+		service.PlanUpdateable = *update.PlanUpdateable
+	}
+	if update.Requires != nil {
+		service.Requires = update.Requires
+	}
+
+	metadata := brokermodel.MetaData{}
+	for key, value := range service.Metadata {
+		metadata[key] = value
+	}
+	if update.DisplayName != "" {
+		metadata[serviceDisplayNameKey] = update.DisplayName
+	}
+	if update.ImageURL != "" {
+		metadata[serviceImageURLKey] = update.ImageURL
+	}
+	if update.DocumentationURL != "" {
+		metadata[serviceDocumentationURLKey] = update.DocumentationURL
+	}
+	service.Metadata = metadata
+
+	return service
+}
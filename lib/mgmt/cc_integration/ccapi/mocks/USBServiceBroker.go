@@ -1,5 +1,6 @@
 package mocks
 
+import "github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
 import "github.com/stretchr/testify/mock"
 
 type USBServiceBroker struct {
@@ -7,11 +8,11 @@ type USBServiceBroker struct {
 }
 
 // Create provides a mock function with given fields: name, url, username, password
-func (_m *USBServiceBroker) Create(name string, url string, username string, password string) error {
+func (_m *USBServiceBroker) Create(name ccapi.BrokerName, url string, username string, password string) error {
 	ret := _m.Called(name, url, username, password)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+	if rf, ok := ret.Get(0).(func(ccapi.BrokerName, string, string, string) error); ok {
 		r0 = rf(name, url, username, password)
 	} else {
 		r0 = ret.Error(0)
@@ -21,11 +22,11 @@ func (_m *USBServiceBroker) Create(name string, url string, username string, pas
 }
 
 // Delete provides a mock function with given fields: name
-func (_m *USBServiceBroker) Delete(name string) error {
+func (_m *USBServiceBroker) Delete(name ccapi.BrokerName) error {
 	ret := _m.Called(name)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
+	if rf, ok := ret.Get(0).(func(ccapi.BrokerName) error); ok {
 		r0 = rf(name)
 	} else {
 		r0 = ret.Error(0)
@@ -35,11 +36,11 @@ func (_m *USBServiceBroker) Delete(name string) error {
 }
 
 // Update provides a mock function with given fields: serviceBrokerGUID, name, url, username, password
-func (_m *USBServiceBroker) Update(serviceBrokerGUID string, name string, url string, username string, password string) error {
+func (_m *USBServiceBroker) Update(serviceBrokerGUID ccapi.BrokerGUID, name ccapi.BrokerName, url string, username string, password string) error {
 	ret := _m.Called(serviceBrokerGUID, name, url, username, password)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string) error); ok {
+	if rf, ok := ret.Get(0).(func(ccapi.BrokerGUID, ccapi.BrokerName, string, string, string) error); ok {
 		r0 = rf(serviceBrokerGUID, name, url, username, password)
 	} else {
 		r0 = ret.Error(0)
@@ -48,13 +49,13 @@ func (_m *USBServiceBroker) Update(serviceBrokerGUID string, name string, url st
 	return r0
 }
 
-// EnableServiceAccess provides a mock function with given fields: serviceID
-func (_m *USBServiceBroker) EnableServiceAccess(serviceID string) error {
-	ret := _m.Called(serviceID)
+// UpdateAll provides a mock function with given fields: url, username, password
+func (_m *USBServiceBroker) UpdateAll(url string, username string, password string) error {
+	ret := _m.Called(url, username, password)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(serviceID)
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(url, username, password)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,20 +63,34 @@ func (_m *USBServiceBroker) EnableServiceAccess(serviceID string) error {
 	return r0
 }
 
-// GetServiceBrokerGUIDByName provides a mock function with given fields: name
-func (_m *USBServiceBroker) GetServiceBrokerGUIDByName(name string) (string, error) {
-	ret := _m.Called(name)
+// EnableServiceAccess provides a mock function with given fields: _a0
+func (_m *USBServiceBroker) EnableServiceAccess(_a0 ccapi.ServiceGUID) error {
+	ret := _m.Called(_a0)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ccapi.ServiceGUID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetServiceBrokerGUIDByName provides a mock function with given fields: _a0
+func (_m *USBServiceBroker) GetServiceBrokerGUIDByName(_a0 ccapi.BrokerName) (ccapi.BrokerGUID, error) {
+	ret := _m.Called(_a0)
+
+	var r0 ccapi.BrokerGUID
+	if rf, ok := ret.Get(0).(func(ccapi.BrokerName) ccapi.BrokerGUID); ok {
+		r0 = rf(_a0)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(ccapi.BrokerGUID)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(ccapi.BrokerName) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -83,20 +98,41 @@ func (_m *USBServiceBroker) GetServiceBrokerGUIDByName(name string) (string, err
 	return r0, r1
 }
 
-// CheckServiceNameExists provides a mock function with given fields: name
-func (_m *USBServiceBroker) CheckServiceNameExists(name string) (bool, error) {
-	ret := _m.Called(name)
+// GetServiceGUIDByName provides a mock function with given fields: _a0
+func (_m *USBServiceBroker) GetServiceGUIDByName(_a0 ccapi.ServiceName) (ccapi.ServiceGUID, error) {
+	ret := _m.Called(_a0)
+
+	var r0 ccapi.ServiceGUID
+	if rf, ok := ret.Get(0).(func(ccapi.ServiceName) ccapi.ServiceGUID); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(ccapi.ServiceGUID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ccapi.ServiceName) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckServiceNameExists provides a mock function with given fields: _a0
+func (_m *USBServiceBroker) CheckServiceNameExists(_a0 ccapi.ServiceName) (bool, error) {
+	ret := _m.Called(_a0)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(ccapi.ServiceName) bool); ok {
+		r0 = rf(_a0)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(ccapi.ServiceName) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,16 +140,37 @@ func (_m *USBServiceBroker) CheckServiceNameExists(name string) (bool, error) {
 	return r0, r1
 }
 
-// CheckServiceInstancesExist provides a mock function with given fields: serviceName
-func (_m *USBServiceBroker) CheckServiceInstancesExist(serviceName string) bool {
-	ret := _m.Called(serviceName)
+// CheckServiceInstancesExist provides a mock function with given fields: _a0
+func (_m *USBServiceBroker) CheckServiceInstancesExist(_a0 ccapi.ServiceName) bool {
+	ret := _m.Called(_a0)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(serviceName)
+	if rf, ok := ret.Get(0).(func(ccapi.ServiceName) bool); ok {
+		r0 = rf(_a0)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
+
+// CheckPlanServiceInstancesExist provides a mock function with given fields: planID
+func (_m *USBServiceBroker) CheckPlanServiceInstancesExist(planID string) (bool, error) {
+	ret := _m.Called(planID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(planID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -21,6 +21,7 @@ type USBServiceBroker interface {
 	GetServiceGUIDByName(ServiceName) (ServiceGUID, error)
 	CheckServiceNameExists(ServiceName) (bool, error)
 	CheckServiceInstancesExist(ServiceName) bool
+	CheckPlanServiceInstancesExist(planID string) (bool, error)
 }
 
 //ServiceBroker is the definition of ServiceBroker type
@@ -41,8 +42,8 @@ type BrokerName string
 type BrokerEntity struct {
 	Name         BrokerName `json:"name,omitempty"`
 	BrokerURL    string     `json:"broker_url"`
-	AuthUsername string     `json:"auth_username"`
-	AuthPassword string     `json:"auth_password"`
+	AuthUsername string     `json:"auth_username,omitempty"`
+	AuthPassword string     `json:"auth_password,omitempty"`
 }
 
 //BrokerResources holds the resources for the broker. Is mapped to json:resources
@@ -110,7 +111,8 @@ func (sb *ServiceBroker) Create(name BrokerName, url, username, password string)
 		"Authorization": string(token),
 	}
 
-	log.Debug("preparing-request", lager.Data{"request-content": string(values)})
+	// The request carries the broker credentials, it is not logged
+	log.Debug("preparing-request")
 
 	request := httpclient.Request{Verb: "POST", Endpoint: sb.ccAPI, APIURL: path, Body: strings.NewReader(string(values)), Headers: headers, StatusCode: 201}
 
@@ -127,7 +129,7 @@ func (sb *ServiceBroker) Create(name BrokerName, url, username, password string)
 	return nil
 }
 
-//Update updates a service broker
+//Update updates a service broker, the cloud controller keeps the credentials it has if username and password are empty
 func (sb *ServiceBroker) Update(serviceBrokerGUID BrokerGUID, name BrokerName, url, username, password string) error {
 	log := sb.logger.Session("update-broker", lager.Data{"name": name, "url": url})
 	log.Debug("starting")
@@ -150,7 +152,8 @@ func (sb *ServiceBroker) Update(serviceBrokerGUID BrokerGUID, name BrokerName, u
 		return err
 	}
 
-	log.Debug("preparing-request", lager.Data{"request-content": string(values)})
+	// The request carries the broker credentials, it is not logged
+	log.Debug("preparing-request")
 
 	request := httpclient.Request{Verb: "PUT", Endpoint: sb.ccAPI, APIURL: path, Body: strings.NewReader(string(values)), Headers: headers, StatusCode: 200}
 
@@ -373,6 +376,71 @@ func (sb *ServiceBroker) CheckServiceInstancesExist(serviceName ServiceName) boo
 	return exist
 }
 
+//CheckPlanServiceInstancesExist checks if the cloud controller knows service instances of the plan the broker offers
+//with planID
+func (sb *ServiceBroker) CheckPlanServiceInstancesExist(planID string) (bool, error) {
+	log := sb.logger.Session("check-plan-service-instances-exist", lager.Data{"plan-id": planID})
+	log.Debug("starting")
+	defer log.Debug("finished")
+
+	token, err := sb.tokenGenerator.GetToken()
+	if err != nil {
+		log.Error("get-token-error", err)
+		return false, err
+	}
+
+	headers := map[string]string{
+		"Authorization": string(token),
+		"Content-Type":  "application/x-www-form-urlencoded; charset=UTF-8",
+		"Accept":        "application/json; charset=utf-8",
+	}
+
+	path := fmt.Sprintf("/v2/service_plans?q=unique_id:%s", planID)
+
+	findRequest := httpclient.Request{Verb: "GET", Endpoint: sb.ccAPI, APIURL: path, Headers: headers, StatusCode: 200}
+
+	log.Info("starting-cc-request-service_plans", lager.Data{"path": path})
+
+	responsePlans, err := sb.client.Request(findRequest)
+	if err != nil {
+		log.Error("client-request-error-service_plans", err)
+		return false, err
+	}
+
+	servicePlans := &PlanResources{}
+	err = json.Unmarshal(responsePlans, servicePlans)
+	if err != nil {
+		log.Error("unmarshal-service-plans-resources", err)
+		return false, err
+	}
+
+	for _, plan := range servicePlans.Resources {
+		path := fmt.Sprintf("/v2/service_plans/%s/service_instances", plan.Metadata.GUID)
+
+		findRequest := httpclient.Request{Verb: "GET", Endpoint: sb.ccAPI, APIURL: path, Headers: headers, StatusCode: 200}
+
+		log.Info("starting-cc-request-service_instances", lager.Data{"path": path})
+
+		responseInstances, err := sb.client.Request(findRequest)
+		if err != nil {
+			log.Error("client-request-error-service_instances", err)
+			return false, err
+		}
+
+		resourcesInstances := &ServiceInstanceResources{}
+		err = json.Unmarshal(responseInstances, resourcesInstances)
+		if err != nil {
+			log.Error("unmarshal-service-instances-resources", err)
+			return false, err
+		}
+		if len(resourcesInstances.Resources) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 //Delete deletes the service with the given name
 func (sb *ServiceBroker) Delete(name BrokerName) error {
 	log := sb.logger.Session("delete-broker", lager.Data{"name": name})
@@ -7,6 +7,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/metrics"
 )
 
 //HTTPClient defines a HTTPClient
@@ -71,21 +74,23 @@ func (client *httpClient) httpRequest(req Request) ([]byte, error) {
 	}
 	httpClient := &http.Client{Transport: tr}
 
+	start := time.Now()
 	response, err := httpClient.Do(request)
 	if err != nil {
+		metrics.ObserveClientRequest(req.Endpoint, req.Verb, req.APIURL, 0, time.Since(start), err)
 		return nil, err
 	}
 
 	defer response.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(response.Body)
+	if err == nil && response.StatusCode != req.StatusCode {
+		err = fmt.Errorf("status code: %d, body: %s", response.StatusCode, responseBody)
+	}
+	metrics.ObserveClientRequest(req.Endpoint, req.Verb, req.APIURL, response.StatusCode, time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode != req.StatusCode {
-		return nil, fmt.Errorf("status code: %d, body: %s", response.StatusCode, responseBody)
-	}
-
 	return responseBody, nil
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	uuid "github.com/satori/go.uuid"
@@ -13,12 +14,15 @@ import (
 	"github.com/SUSE/cf-usb/lib/config"
 	"github.com/SUSE/cf-usb/lib/csm"
 	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/SUSE/cf-usb/lib/httpmiddleware"
+	"github.com/SUSE/cf-usb/lib/metrics"
 	"github.com/SUSE/cf-usb/lib/mgmt/authentication"
 	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
 	"github.com/SUSE/cf-usb/lib/mgmt/operations"
 	errors "github.com/go-openapi/errors"
 	runtime "github.com/go-openapi/runtime"
 	middleware "github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -26,9 +30,19 @@ import (
 
 const defaultBrokerName ccapi.BrokerName = "usb"
 
+//serviceTypeRequires maps the service type reported by a sidecar to the catalog requires flag of its service
+var serviceTypeRequires = map[string]string{
+	"routing": "route_forwarding",
+	"syslog":  "syslog_drain",
+	"volume":  "volume_mount",
+}
+
+//newCSMClient builds the uncached client used to validate a driver endpoint before it is stored
+var newCSMClient = csm.NewCSMClient
+
 //ConfigureAPI configures UsbMgmtApi with Interface, config Provider, USBServiceBroker, Logger and a version string
 func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication,
-	configProvider config.Provider, ccServiceBroker ccapi.USBServiceBroker, csmClient csm.CSM,
+	configProvider config.Provider, ccServiceBroker ccapi.USBServiceBroker, csmClients csm.ClientCache,
 	logger lager.Logger, usbVersion string) http.Handler {
 
 	// configure the api here
@@ -52,6 +66,223 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		return true, nil
 	}
 
+	api.CreateDialHandler = operations.CreateDialHandlerFunc(func(params operations.CreateDialParams, principal interface{}) middleware.Responder {
+		log := log.Session("create-dial")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID, "dial-name": params.Dial.Name})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.CreateDialNotFound{}
+		}
+
+		if strings.ContainsAny(*params.Dial.Name, " ") {
+			return &operations.CreateDialBadRequest{Payload: "Dial name cannot contain spaces"}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+		if dialPlanNameExists(driverInstance.Dials, *params.Dial.Name, "") {
+			return &operations.CreateDialConflict{Payload: fmt.Sprintf("A dial named '%s' already exists for driver endpoint '%s'", *params.Dial.Name, instance.Name)}
+		}
+
+		dialID := uuid.NewV4().String()
+		dial, err := newDial(params.Dial, uuid.NewV4().String())
+		if err != nil {
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+
+		err = configProvider.SetDial(params.DriverEndpointID, dialID, dial)
+		if err != nil {
+			log.Error("set-dial-failed", err)
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+
+		err = refreshCatalog(log, configProvider, ccServiceBroker, instance.Name)
+		if err != nil {
+			log.Error("refresh-catalog-failed", err)
+			if err := configProvider.DeleteDial(dialID); err != nil {
+				log.Error("rollback-set-dial-failed", err)
+			}
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+
+		response, err := dialModel(dialID, dial)
+		if err != nil {
+			return &operations.CreateDialInternalServerError{Payload: err.Error()}
+		}
+
+		return &operations.CreateDialCreated{Payload: response}
+	})
+
+	api.DeleteDialHandler = operations.DeleteDialHandlerFunc(func(params operations.DeleteDialParams, principal interface{}) middleware.Responder {
+		log := log.Session("delete-dial")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID, "dial-id": params.DialID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.DeleteDialNotFound{}
+		}
+
+		dial, dialInstanceID, err := configProvider.GetDial(params.DialID)
+		if err != nil {
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+		if dial == nil || dialInstanceID != params.DriverEndpointID {
+			return &operations.DeleteDialNotFound{}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+		if len(driverInstance.Dials) <= 1 {
+			return &operations.DeleteDialConflict{Payload: fmt.Sprintf("Cannot delete dial '%s', it is the last dial of driver endpoint '%s'", dial.Plan.Name, instance.Name)}
+		}
+
+		inUse, err := planHasServiceInstances(configProvider, ccServiceBroker, dial.Plan.ID)
+		if err != nil {
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+		if inUse {
+			return &operations.DeleteDialConflict{Payload: fmt.Sprintf("Cannot delete dial '%s', its plan still has provisioned service instances", dial.Plan.Name)}
+		}
+
+		err = configProvider.DeleteDial(params.DialID)
+		if err != nil {
+			log.Error("delete-dial-failed", err)
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+
+		err = refreshCatalog(log, configProvider, ccServiceBroker, instance.Name)
+		if err != nil {
+			log.Error("refresh-catalog-failed", err)
+			if err := configProvider.SetDial(params.DriverEndpointID, params.DialID, *dial); err != nil {
+				log.Error("rollback-delete-dial-failed", err)
+			}
+			return &operations.DeleteDialInternalServerError{Payload: err.Error()}
+		}
+
+		return &operations.DeleteDialNoContent{}
+	})
+
+	api.GetDialHandler = operations.GetDialHandlerFunc(func(params operations.GetDialParams, principal interface{}) middleware.Responder {
+		log := log.Session("get-dial")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID, "dial-id": params.DialID})
+
+		dial, dialInstanceID, err := configProvider.GetDial(params.DialID)
+		if err != nil {
+			return &operations.GetDialInternalServerError{Payload: err.Error()}
+		}
+		if dial == nil || dialInstanceID != params.DriverEndpointID {
+			return &operations.GetDialNotFound{}
+		}
+
+		response, err := dialModel(params.DialID, *dial)
+		if err != nil {
+			return &operations.GetDialInternalServerError{Payload: err.Error()}
+		}
+
+		return &operations.GetDialOK{Payload: response}
+	})
+
+	api.GetDialsHandler = operations.GetDialsHandlerFunc(func(params operations.GetDialsParams, principal interface{}) middleware.Responder {
+		log := log.Session("get-dials")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.GetDialsInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.GetDialsNotFound{}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.GetDialsInternalServerError{Payload: err.Error()}
+		}
+
+		response := []*genmodel.Dial{}
+		for dialID, dial := range driverInstance.Dials {
+			model, err := dialModel(dialID, dial)
+			if err != nil {
+				return &operations.GetDialsInternalServerError{Payload: err.Error()}
+			}
+			response = append(response, model)
+		}
+		sort.Sort(dialsByName(response))
+
+		return &operations.GetDialsOK{Payload: response}
+	})
+
+	api.UpdateDialHandler = operations.UpdateDialHandlerFunc(func(params operations.UpdateDialParams, principal interface{}) middleware.Responder {
+		log := log.Session("update-dial")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID, "dial-id": params.DialID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.UpdateDialNotFound{}
+		}
+
+		previous, dialInstanceID, err := configProvider.GetDial(params.DialID)
+		if err != nil {
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+		if previous == nil || dialInstanceID != params.DriverEndpointID {
+			return &operations.UpdateDialNotFound{}
+		}
+
+		if strings.ContainsAny(*params.Dial.Name, " ") {
+			return &operations.UpdateDialBadRequest{Payload: "Dial name cannot contain spaces"}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+		if dialPlanNameExists(driverInstance.Dials, *params.Dial.Name, params.DialID) {
+			return &operations.UpdateDialConflict{Payload: fmt.Sprintf("A dial named '%s' already exists for driver endpoint '%s'", *params.Dial.Name, instance.Name)}
+		}
+
+		dial, err := newDial(params.Dial, previous.Plan.ID)
+		if err != nil {
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+
+		err = configProvider.SetDial(params.DriverEndpointID, params.DialID, dial)
+		if err != nil {
+			log.Error("set-dial-failed", err)
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+
+		err = refreshCatalog(log, configProvider, ccServiceBroker, instance.Name)
+		if err != nil {
+			log.Error("refresh-catalog-failed", err)
+			if err := configProvider.SetDial(params.DriverEndpointID, params.DialID, *previous); err != nil {
+				log.Error("rollback-set-dial-failed", err)
+			}
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+
+		response, err := dialModel(params.DialID, dial)
+		if err != nil {
+			return &operations.UpdateDialInternalServerError{Payload: err.Error()}
+		}
+
+		return &operations.UpdateDialOK{Payload: response}
+	})
+
 	api.GetDriverEndpointHandler = operations.GetDriverEndpointHandlerFunc(func(params operations.GetDriverEndpointParams, principal interface{}) middleware.Responder {
 		log := log.Session("get-driver-endpoint")
 		log.Info("request", lager.Data{"instance-id": params.DriverEndpointID})
@@ -64,15 +295,27 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 			return &operations.GetDriverEndpointNotFound{}
 		}
 
-		driverEndpoint := &genmodel.DriverEndpoint{
-			ID:                params.DriverEndpointID,
-			Name:              &endpoint.Name,
-			EndpointURL:       endpoint.TargetURL,
-			AuthenticationKey: endpoint.AuthenticationKey,
-			Metadata:          map[string]string(endpoint.Service.Metadata),
+		return &operations.GetDriverEndpointOK{Payload: driverEndpointModel(params.DriverEndpointID, *endpoint)}
+	})
+
+	api.GetDriverEndpointServiceHandler = operations.GetDriverEndpointServiceHandlerFunc(func(params operations.GetDriverEndpointServiceParams, principal interface{}) middleware.Responder {
+		log := log.Session("get-driver-endpoint-service")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.GetDriverEndpointServiceInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.GetDriverEndpointServiceNotFound{}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.GetDriverEndpointServiceInternalServerError{Payload: err.Error()}
 		}
 
-		return &operations.GetDriverEndpointOK{Payload: driverEndpoint}
+		return &operations.GetDriverEndpointServiceOK{Payload: endpointService(driverInstance.Service)}
 	})
 
 	api.GetDriverEndpointsHandler = operations.GetDriverEndpointsHandlerFunc(func(principal interface{}) middleware.Responder {
@@ -84,21 +327,7 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 
 		var response []*genmodel.DriverEndpoint
 		for id, endpoint := range config.Instances {
-
-			var name string
-			name = endpoint.Name
-
-			driverEndpoint := &genmodel.DriverEndpoint{
-				ID:                id,
-				Name:              &name,
-				EndpointURL:       endpoint.TargetURL,
-				AuthenticationKey: endpoint.AuthenticationKey,
-				SkipSSLValidation: &endpoint.SkipSsl,
-				CaCertificate:     endpoint.CaCert,
-				Metadata:          map[string]string(endpoint.Service.Metadata),
-			}
-
-			response = append(response, driverEndpoint)
+			response = append(response, driverEndpointModel(id, endpoint))
 		}
 		return &operations.GetDriverEndpointsOK{Payload: response}
 	})
@@ -125,9 +354,30 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 	})
 
 	api.PingDriverEndpointHandler = operations.PingDriverEndpointHandlerFunc(func(params operations.PingDriverEndpointParams, principal interface{}) middleware.Responder {
-		// TODO Implement Ping
+		log := log.Session("ping-driver-endpoint")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			log.Error("get-driver-endpoint-failed", err)
+			return &operations.PingDriverEndpointInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.PingDriverEndpointNotFound{}
+		}
+
+		csmClient, err := csmClients.GetClient(params.DriverEndpointID, instanceEndpoint(*instance))
+		if err != nil {
+			log.Error("csm-client-failed", err)
+			return &operations.PingDriverEndpointInternalServerError{Payload: err.Error()}
+		}
 
-		return &operations.PingDriverEndpointOK{}
+		result := pingDriverEndpoint(csmClient.WithRequestID(httpmiddleware.RequestID(params.HTTPRequest)))
+		if result.ErrorType != "" {
+			log.Info("driver-endpoint-unhealthy", lager.Data{"error-type": result.ErrorType, "error": result.Error, "latency-ms": result.LatencyMs})
+		}
+
+		return &operations.PingDriverEndpointOK{Payload: result}
 	})
 
 	api.RegisterDriverEndpointHandler = operations.RegisterDriverEndpointHandlerFunc(func(params operations.RegisterDriverEndpointParams, principal interface{}) middleware.Responder {
@@ -135,7 +385,7 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		log.Info("request", lager.Data{"id": params.DriverEndpoint.ID, "driver-endpoint-name": params.DriverEndpoint.Name, "driver-endpoint-url": params.DriverEndpoint.EndpointURL})
 
 		if strings.ContainsAny(*params.DriverEndpoint.Name, " ") {
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: fmt.Sprintf("Driver endpoint name cannot contain spaces")}
+			return &operations.RegisterDriverEndpointInternalServerError{Payload: &genmodel.RegistrationError{Message: "Driver endpoint name cannot contain spaces"}}
 		}
 
 		var instance config.Instance
@@ -152,16 +402,16 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 			instance.SkipSsl = *params.DriverEndpoint.SkipSSLValidation
 		}
 
-		instance.CaCert = params.DriverEndpoint.CaCertificate
+		instance.CaCert = swag.StringValue(params.DriverEndpoint.CaCertificate)
 
 		driverInstanceNameExist, err := configProvider.InstanceNameExists(*params.DriverEndpoint.Name)
 		if err != nil {
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return &operations.RegisterDriverEndpointInternalServerError{Payload: &genmodel.RegistrationError{Message: err.Error()}}
 		}
 
 		serviceNameExist, err := ccServiceBroker.CheckServiceNameExists(ccapi.ServiceName(*params.DriverEndpoint.Name))
 		if err != nil {
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return &operations.RegisterDriverEndpointInternalServerError{Payload: &genmodel.RegistrationError{Message: err.Error()}}
 		}
 
 		var createInstanceError error
@@ -180,26 +430,71 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 
 		instance.Name = *params.DriverEndpoint.Name
 
-		err = csmClient.Login(instance.TargetURL, instance.AuthenticationKey, instance.CaCert, instance.SkipSsl)
+		var defaultDial config.Dial
+		var accounts []config.BrokerAccount
+
+		config, err := configProvider.LoadConfiguration()
 		if err != nil {
-			log.Error("csm-login-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			log.Error("load-configuration-failed", err)
+			return &operations.RegisterDriverEndpointInternalServerError{Payload: &genmodel.RegistrationError{Message: err.Error()}}
 		}
 
-		log.Debug("get-status-information", lager.Data{"url": instance.TargetURL})
-		serviceType, err := csmClient.GetStatus()
-		if err != nil {
-			log.Error("csm-get-status", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+		brokerName := defaultBrokerName
+		if len(config.ManagementAPI.BrokerName) > 0 {
+			brokerName = ccapi.BrokerName(config.ManagementAPI.BrokerName)
 		}
 
-		err = configProvider.SetInstance(instanceID, instance)
+		tx := newTransaction(log)
+		fail := func(err error) middleware.Responder {
+			return &operations.RegisterDriverEndpointInternalServerError{Payload: tx.rollback(err)}
+		}
+
+		var serviceType string
+		err = tx.run("check-driver-endpoint", func() error {
+			csmClient, err := csmClients.GetClient(instanceID, instanceEndpoint(instance))
+			if err != nil {
+				return err
+			}
+
+			log.Debug("get-status-information", lager.Data{"url": instance.TargetURL})
+			serviceType, err = csmClient.WithRequestID(httpmiddleware.RequestID(params.HTTPRequest)).GetStatus()
+			if err != nil {
+				csmClients.Remove(instanceID)
+			}
+			return err
+		}, func() error {
+			csmClients.Remove(instanceID)
+			return nil
+		})
 		if err != nil {
-			log.Error("set-driver-instance-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
+		}
+
+		//when the broker is updated the cloud controller fetches the catalog with the new service, so once the
+		//configuration is rolled back it has to fetch the catalog again
+		var brokerGUID ccapi.BrokerGUID
+		brokerUpdated := false
+		err = tx.run("get-service-broker", func() error {
+			brokerGUID, err = ccServiceBroker.GetServiceBrokerGUIDByName(brokerName)
+			return err
+		}, func() error {
+			if !brokerUpdated {
+				return nil
+			}
+			return ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, "", "")
+		})
+		if err != nil {
+			return fail(err)
 		}
 
-		var defaultDial config.Dial
+		err = tx.run("set-instance", func() error {
+			return configProvider.SetInstance(instanceID, instance)
+		}, func() error {
+			return configProvider.DeleteInstance(instanceID)
+		})
+		if err != nil {
+			return fail(err)
+		}
 
 		defaultDialID := uuid.NewV4().String()
 
@@ -222,10 +517,13 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		defaultDialConfig := json.RawMessage([]byte("{}"))
 		defaultDial.Configuration = &defaultDialConfig
 
-		err = configProvider.SetDial(instanceID, defaultDialID, defaultDial)
+		err = tx.run("set-dial", func() error {
+			return configProvider.SetDial(instanceID, defaultDialID, defaultDial)
+		}, func() error {
+			return configProvider.DeleteDial(defaultDialID)
+		})
 		if err != nil {
-			log.Error("set-dial-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
 		}
 
 		var service brokermodel.CatalogService
@@ -236,55 +534,61 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		service.Tags = []string{service.Name}
 		service.Bindable = true
 		service.Metadata = map[string]string(params.DriverEndpoint.Metadata)
+		service.DashboardClient = catalogDashboardClient(params.DriverEndpoint.DashboardClient)
 
-		if serviceType == "routing" {
-			service.Requires = []string{"route_forwarding"}
-		}
-
-		err = configProvider.SetService(instanceID, service)
-		if err != nil {
-			log.Error("set-service-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+		if requires, ok := serviceTypeRequires[serviceType]; ok {
+			service.Requires = []string{requires}
 		}
 
-		config, err := configProvider.LoadConfiguration()
+		err = tx.run("set-service", func() error {
+			return configProvider.SetService(instanceID, service)
+		}, func() error {
+			return configProvider.DeleteService(instanceID)
+		})
 		if err != nil {
-			log.Error("load-configuration-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
 		}
 
-		brokerName := defaultBrokerName
-		if len(config.ManagementAPI.BrokerName) > 0 {
-			brokerName = ccapi.BrokerName(config.ManagementAPI.BrokerName)
-		}
-
-		brokerGUID, err := ccServiceBroker.GetServiceBrokerGUIDByName(brokerName)
-		if err != nil {
-			log.Error("get-service-broker-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
-		}
 		log.Info("create-or-update-service-broker", lager.Data{"guid": brokerGUID})
-
 		if brokerGUID == "" {
-			err = ccServiceBroker.Create(brokerName, config.BrokerAPI.ExternalURL, config.BrokerAPI.Credentials.Username, config.BrokerAPI.Credentials.Password)
+			//creating the broker may rotate its password, the accounts are restored once the broker is deleted again
+			err = tx.run("create-service-broker", func() error {
+				accounts, err = configProvider.GetBrokerAccounts()
+				if err != nil {
+					return err
+				}
+				return createServiceBroker(log, configProvider, ccServiceBroker, brokerName, config.BrokerAPI)
+			}, func() error {
+				err := ccServiceBroker.Delete(brokerName)
+				if err != nil {
+					return err
+				}
+				return setBrokerAccounts(configProvider, accounts)
+			})
 		} else {
-			err = ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, config.BrokerAPI.Credentials.Username, config.BrokerAPI.Credentials.Password)
+			err = tx.run("update-service-broker", func() error {
+				brokerUpdated = true
+				return ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, "", "")
+			}, nil)
 		}
 		if err != nil {
-			log.Error("create-or-update-service-broker-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
 		}
 
-		serviceGUID, err := ccServiceBroker.GetServiceGUIDByName(ccapi.ServiceName(*params.DriverEndpoint.Name))
+		var serviceGUID ccapi.ServiceGUID
+		err = tx.run("get-service-guid", func() error {
+			serviceGUID, err = ccServiceBroker.GetServiceGUIDByName(ccapi.ServiceName(*params.DriverEndpoint.Name))
+			return err
+		}, nil)
 		if err != nil {
-			log.Error("get-service-guid-by-name-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
 		}
 
-		err = ccServiceBroker.EnableServiceAccess(serviceGUID)
+		err = tx.run("enable-service-access", func() error {
+			return ccServiceBroker.EnableServiceAccess(serviceGUID)
+		}, nil)
 		if err != nil {
-			log.Error("enable-service-access-failed", err)
-			return &operations.RegisterDriverEndpointInternalServerError{Payload: err.Error()}
+			return fail(err)
 		}
 
 		return &operations.RegisterDriverEndpointCreated{Payload: params.DriverEndpoint}
@@ -308,6 +612,7 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		if err != nil {
 			return &operations.UnregisterDriverInstanceInternalServerError{Payload: err.Error()}
 		}
+		csmClients.Remove(params.DriverEndpointID)
 
 		config, err := configProvider.LoadConfiguration()
 		if err != nil {
@@ -337,7 +642,7 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 				return &operations.UnregisterDriverInstanceInternalServerError{Payload: err.Error()}
 			}
 
-			err = ccServiceBroker.Update(guid, brokerName, config.BrokerAPI.ExternalURL, config.BrokerAPI.Credentials.Username, config.BrokerAPI.Credentials.Password)
+			err = ccServiceBroker.Update(guid, brokerName, config.BrokerAPI.ExternalURL, "", "")
 			if err != nil {
 				log.Error("update-service-broker-failed", err)
 				return &operations.UnregisterDriverInstanceInternalServerError{Payload: err.Error()}
@@ -370,7 +675,7 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		if brokerGUID == "" {
 			return &operations.UpdateCatalogInternalServerError{Payload: fmt.Sprintf("Broker %s guid not found", brokerName)}
 		}
-		err = ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, config.BrokerAPI.Credentials.Username, config.BrokerAPI.Credentials.Password)
+		err = ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, "", "")
 		if err != nil {
 			return &operations.UpdateCatalogInternalServerError{Payload: err.Error()}
 		}
@@ -391,6 +696,44 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 		return &operations.UpdateCatalogOK{}
 	})
 
+	api.RotateBrokerPasswordHandler = operations.RotateBrokerPasswordHandlerFunc(func(principal interface{}) middleware.Responder {
+		log := log.Session("rotate-broker-password")
+		log.Info("request")
+
+		config, err := configProvider.LoadConfiguration()
+		if err != nil {
+			return &operations.RotateBrokerPasswordInternalServerError{Payload: err.Error()}
+		}
+
+		brokerName := defaultBrokerName
+		if len(config.ManagementAPI.BrokerName) > 0 {
+			brokerName = ccapi.BrokerName(config.ManagementAPI.BrokerName)
+		}
+
+		brokerGUID, err := ccServiceBroker.GetServiceBrokerGUIDByName(brokerName)
+		if err != nil {
+			log.Error("get-service-broker-failed", err)
+			return &operations.RotateBrokerPasswordInternalServerError{Payload: err.Error()}
+		}
+
+		if brokerGUID == "" {
+			return &operations.RotateBrokerPasswordInternalServerError{Payload: fmt.Sprintf("Broker %s guid not found", brokerName)}
+		}
+
+		username := config.BrokerAPI.Credentials.Username
+		err = rotateBrokerPassword(log, configProvider, config.BrokerAPI.Credentials, func(password string) error {
+			return ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, username, password)
+		})
+		if err != nil {
+			log.Error("rotate-broker-password-failed", err)
+			return &operations.RotateBrokerPasswordInternalServerError{Payload: err.Error()}
+		}
+
+		log.Info("broker-password-rotated", lager.Data{"username": username})
+
+		return &operations.RotateBrokerPasswordOK{}
+	})
+
 	api.UpdateDriverEndpointHandler = operations.UpdateDriverEndpointHandlerFunc(func(params operations.UpdateDriverEndpointParams, principal interface{}) middleware.Responder {
 		log := log.Session("update-driver-endpoint")
 		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID})
@@ -403,49 +746,109 @@ func ConfigureAPI(api *operations.UsbMgmtAPI, auth authentication.Authentication
 			return &operations.UpdateDriverEndpointNotFound{}
 		}
 
-		instance := *instanceInfo
+		previous, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.UpdateDriverEndpointInternalServerError{Payload: err.Error()}
+		}
+
+		instance := updateDriverEndpoint(*previous, params.DriverEndpoint)
+
+		if instance.Name != previous.Name {
+			if strings.ContainsAny(instance.Name, " ") {
+				return &operations.UpdateDriverEndpointInternalServerError{Payload: fmt.Sprintf("Driver endpoint name cannot contain spaces")}
+			}
+
+			driverInstanceNameExist, err := configProvider.InstanceNameExists(instance.Name)
+			if err != nil {
+				return &operations.UpdateDriverEndpointInternalServerError{Payload: err.Error()}
+			}
 
-		if instanceInfo.Name != *params.DriverEndpoint.Name {
-			driverInstanceNameExist, err := configProvider.InstanceNameExists(*params.DriverEndpoint.Name)
+			serviceNameExist, err := ccServiceBroker.CheckServiceNameExists(ccapi.ServiceName(instance.Name))
 			if err != nil {
 				return &operations.UpdateDriverEndpointInternalServerError{Payload: err.Error()}
 			}
 
-			if driverInstanceNameExist {
-				err := fmt.Errorf("A driver instance with the same name already exists")
+			if driverInstanceNameExist || serviceNameExist {
+				err := fmt.Errorf("A driver instance or a service with the same name already exists")
 				log.Error("check-driver-instance-name-exist", err)
-				return &operations.UpdateDriverEndpointConflict{}
+				return &operations.UpdateDriverEndpointConflict{Payload: err.Error()}
 			}
 		}
 
-		if params.DriverEndpoint.AuthenticationKey != "" {
-			instance.AuthenticationKey = params.DriverEndpoint.AuthenticationKey
-		}
-		if params.DriverEndpoint.EndpointURL != "" {
-			instance.TargetURL = params.DriverEndpoint.EndpointURL
+		csmClient, err := newCSMClient(log, instanceEndpoint(instance))
+		if err != nil {
+			log.Error("csm-client-failed", err)
+			return &operations.UpdateDriverEndpointBadRequest{Payload: err.Error()}
 		}
 
-		instance.Service.Metadata = map[string]string(params.DriverEndpoint.Metadata)
+		log.Debug("get-status-information", lager.Data{"url": instance.TargetURL})
+		_, err = csmClient.WithRequestID(httpmiddleware.RequestID(params.HTTPRequest)).GetStatus()
+		if err != nil {
+			log.Error("csm-get-status", err)
+			return &operations.UpdateDriverEndpointBadRequest{Payload: err.Error()}
+		}
 
 		err = configProvider.SetInstance(params.DriverEndpointID, instance)
 		if err != nil {
+			log.Error("set-driver-instance-failed", err)
+			return &operations.UpdateDriverEndpointInternalServerError{Payload: err.Error()}
+		}
+		csmClients.Remove(params.DriverEndpointID)
+
+		err = refreshCatalog(log, configProvider, ccServiceBroker, instance.Name)
+		if err != nil {
+			log.Error("refresh-catalog-failed", err)
+			if err := configProvider.SetInstance(params.DriverEndpointID, *previous); err != nil {
+				log.Error("rollback-set-driver-instance-failed", err)
+			}
+			csmClients.Remove(params.DriverEndpointID)
 			return &operations.UpdateDriverEndpointInternalServerError{Payload: err.Error()}
 		}
 
-		driverEndpoint := &genmodel.DriverEndpoint{
-			ID:                params.DriverEndpointID,
-			Name:              &instance.Name,
-			EndpointURL:       instance.TargetURL,
-			AuthenticationKey: instance.AuthenticationKey,
-			Metadata:          map[string]string(params.DriverEndpoint.Metadata),
+		return &operations.UpdateDriverEndpointOK{Payload: driverEndpointModel(params.DriverEndpointID, instance)}
+	})
+
+	api.UpdateDriverEndpointServiceHandler = operations.UpdateDriverEndpointServiceHandlerFunc(func(params operations.UpdateDriverEndpointServiceParams, principal interface{}) middleware.Responder {
+		log := log.Session("update-driver-endpoint-service")
+		log.Info("request", lager.Data{"driver-endpoint-id": params.DriverEndpointID})
+
+		instance, _, err := configProvider.GetInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.UpdateDriverEndpointServiceInternalServerError{Payload: err.Error()}
+		}
+		if instance == nil {
+			return &operations.UpdateDriverEndpointServiceNotFound{}
+		}
+
+		driverInstance, err := configProvider.LoadDriverInstance(params.DriverEndpointID)
+		if err != nil {
+			return &operations.UpdateDriverEndpointServiceInternalServerError{Payload: err.Error()}
+		}
+
+		previous := driverInstance.Service
+		service := updateCatalogService(previous, params.Service)
+
+		err = configProvider.SetService(params.DriverEndpointID, service)
+		if err != nil {
+			log.Error("set-service-failed", err)
+			return &operations.UpdateDriverEndpointServiceInternalServerError{Payload: err.Error()}
+		}
+
+		err = refreshCatalog(log, configProvider, ccServiceBroker, instance.Name)
+		if err != nil {
+			log.Error("refresh-catalog-failed", err)
+			if err := configProvider.SetService(params.DriverEndpointID, previous); err != nil {
+				log.Error("rollback-set-service-failed", err)
+			}
+			return &operations.UpdateDriverEndpointServiceInternalServerError{Payload: err.Error()}
 		}
 
-		return &operations.UpdateDriverEndpointOK{Payload: driverEndpoint}
+		return &operations.UpdateDriverEndpointServiceOK{Payload: endpointService(service)}
 	})
 
 	api.ServerShutdown = func() {}
 
-	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
+	return setupGlobalMiddleware(log, api.Serve(setupMiddlewares))
 }
 
 // The TLS configuration before HTTPS server starts.
@@ -461,6 +864,15 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
-func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return handler
+func setupGlobalMiddleware(log lager.Logger, handler http.Handler) http.Handler {
+	handler = httpmiddleware.Recovery(log, handler, func(w http.ResponseWriter, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(message)
+	})
+	templates, err := metrics.SpecPathTemplates(SwaggerJSON)
+	if err != nil {
+		log.Error("path-templates-failed", err)
+	}
+	return httpmiddleware.RequestIDs(httpmiddleware.AccessLog(log, httpmiddleware.Metrics("management", templates, handler)))
 }
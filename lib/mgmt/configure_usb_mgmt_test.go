@@ -1,20 +1,26 @@
 package mgmt
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
+	"github.com/SUSE/cf-usb/lib/brokermodel"
 	"github.com/SUSE/cf-usb/lib/config"
 	"github.com/SUSE/cf-usb/lib/config/mocks"
-	loads "github.com/go-openapi/loads"
-	//"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/csm"
 	csmMocks "github.com/SUSE/cf-usb/lib/csm/mocks"
 	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/SUSE/cf-usb/lib/metrics"
 	"github.com/SUSE/cf-usb/lib/mgmt/authentication/uaa"
-	//"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
+	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
 	sbMocks "github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi/mocks"
 	"github.com/SUSE/cf-usb/lib/mgmt/operations"
+	loads "github.com/go-openapi/loads"
+	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,6 +31,7 @@ var logger = lagertest.NewTestLogger("mgmt-api-test")
 type mockObjects struct {
 	serviceBroker *sbMocks.USBServiceBroker
 	csmClient     *csmMocks.CSM
+	csmClients    *csmMocks.ClientCache
 	usbMgmt       *operations.UsbMgmtAPI
 }
 
@@ -37,6 +44,10 @@ func initMgmt(provider config.Provider) (mockObjects, error) {
 	}
 	mObjects.usbMgmt = operations.NewUsbMgmtAPI(swaggerSpec)
 	mObjects.csmClient = new(csmMocks.CSM)
+	mObjects.csmClient.Mock.On("WithRequestID", mock.Anything).Return(mObjects.csmClient)
+	mObjects.csmClients = new(csmMocks.ClientCache)
+	mObjects.csmClients.Mock.On("GetClient", mock.Anything, mock.Anything).Return(mObjects.csmClient, nil)
+	mObjects.csmClients.Mock.On("Remove", mock.Anything).Return()
 	mObjects.serviceBroker = new(sbMocks.USBServiceBroker)
 
 	auth, err := uaa.NewUaaAuth("", "", "", "", true, logger)
@@ -44,7 +55,7 @@ func initMgmt(provider config.Provider) (mockObjects, error) {
 		return mObjects, err
 	}
 
-	ConfigureAPI(mObjects.usbMgmt, auth, provider, mObjects.serviceBroker, mObjects.csmClient, logger, "t.t.t")
+	ConfigureAPI(mObjects.usbMgmt, auth, provider, mObjects.serviceBroker, mObjects.csmClients, logger, "t.t.t")
 
 	return mObjects, nil
 }
@@ -111,16 +122,136 @@ func Test_RegisterDriverEndpoint(t *testing.T) {
 	provider.On("SetDial", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	provider.On("SetService", mock.Anything, mock.Anything).Return(nil)
 	mObjects.serviceBroker.Mock.On("CheckServiceNameExists", mock.Anything).Return(false, nil)
-	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return("aguid", nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
 	mObjects.serviceBroker.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	mObjects.serviceBroker.Mock.On("Update", "aguid", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
 	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
-	mObjects.csmClient.Mock.On("Login", params.DriverEndpoint.EndpointURL, params.DriverEndpoint.AuthenticationKey, "", false).Return(nil)
 	mObjects.csmClient.Mock.On("GetStatus").Return("", nil)
 
 	response := mObjects.usbMgmt.RegisterDriverEndpointHandler.Handle(*params, true)
 
 	assert.IsType(&operations.RegisterDriverEndpointCreated{}, response)
+	mObjects.csmClients.AssertCalled(t, "GetClient", mock.Anything, csm.Endpoint{
+		Name:              *params.DriverEndpoint.Name,
+		TargetURL:         params.DriverEndpoint.EndpointURL,
+		AuthenticationKey: params.DriverEndpoint.AuthenticationKey,
+	})
+}
+
+func Test_RegisterDriverEndpointRollback(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	params := &operations.RegisterDriverEndpointParams{}
+	params.DriverEndpoint = &genmodel.DriverEndpoint{}
+	name := "testInstance"
+	params.DriverEndpoint.Name = &name
+	params.DriverEndpoint.EndpointURL = "http://127.0.0.1:8080"
+	params.DriverEndpoint.AuthenticationKey = "authkey"
+
+	var testConfig config.Config
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("InstanceNameExists", mock.Anything).Return(false, nil)
+	provider.On("SetInstance", mock.Anything, mock.Anything).Return(nil)
+	provider.On("SetDial", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	provider.On("SetService", mock.Anything, mock.Anything).Return(nil)
+	provider.On("DeleteInstance", mock.Anything).Return(nil)
+	provider.On("DeleteDial", mock.Anything).Return(nil)
+	provider.On("DeleteService", mock.Anything).Return(fmt.Errorf("service delete failed"))
+	mObjects.serviceBroker.Mock.On("CheckServiceNameExists", mock.Anything).Return(false, nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(fmt.Errorf("access denied"))
+	mObjects.csmClient.Mock.On("GetStatus").Return("", nil)
+
+	response := mObjects.usbMgmt.RegisterDriverEndpointHandler.Handle(*params, true)
+
+	assert.IsType(&operations.RegisterDriverEndpointInternalServerError{}, response)
+	report := response.(*operations.RegisterDriverEndpointInternalServerError).Payload
+	assert.Equal("access denied", report.Message)
+
+	status := make(map[string]string)
+	for _, step := range report.Steps {
+		status[step.Name] = step.Status
+	}
+	assert.Equal(map[string]string{
+		"check-driver-endpoint": "rolled_back",
+		"get-service-broker":    "rolled_back",
+		"set-instance":          "rolled_back",
+		"set-dial":              "rolled_back",
+		"set-service":           "rollback_failed",
+		"update-service-broker": "succeeded",
+		"get-service-guid":      "succeeded",
+		"enable-service-access": "failed",
+	}, status)
+
+	provider.AssertCalled(t, "DeleteInstance", mock.Anything)
+	provider.AssertCalled(t, "DeleteDial", mock.Anything)
+	mObjects.serviceBroker.AssertNumberOfCalls(t, "Update", 2)
+	mObjects.csmClients.AssertCalled(t, "Remove", mock.Anything)
+}
+
+func Test_RegisterDriverEndpointRollbackRestoresBrokerAccounts(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	params := &operations.RegisterDriverEndpointParams{}
+	params.DriverEndpoint = &genmodel.DriverEndpoint{}
+	name := "testInstance"
+	params.DriverEndpoint.Name = &name
+	params.DriverEndpoint.EndpointURL = "http://127.0.0.1:8080"
+	params.DriverEndpoint.AuthenticationKey = "authkey"
+
+	hash, err := config.HashBrokerPassword("rotated")
+	assert.NoError(err)
+	accounts := []config.BrokerAccount{{Username: "broker", PasswordHash: hash}}
+
+	var testConfig config.Config
+	testConfig.BrokerAPI.Credentials = config.BrokerCredentials{Username: "broker", Password: "configured"}
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("InstanceNameExists", mock.Anything).Return(false, nil)
+	provider.On("SetInstance", mock.Anything, mock.Anything).Return(nil)
+	provider.On("SetDial", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	provider.On("SetService", mock.Anything, mock.Anything).Return(nil)
+	provider.On("DeleteInstance", mock.Anything).Return(nil)
+	provider.On("DeleteDial", mock.Anything).Return(nil)
+	provider.On("DeleteService", mock.Anything).Return(nil)
+	provider.On("GetBrokerAccounts").Return(accounts, nil)
+	provider.On("SetBrokerAccounts", mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("CheckServiceNameExists", mock.Anything).Return(false, nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID(""), nil)
+	mObjects.serviceBroker.Mock.On("Create", mock.Anything, mock.Anything, "broker", mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("Delete", ccapi.BrokerName("usb")).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID(""), fmt.Errorf("service not found"))
+	mObjects.csmClient.Mock.On("GetStatus").Return("", nil)
+
+	response := mObjects.usbMgmt.RegisterDriverEndpointHandler.Handle(*params, true)
+	assert.IsType(&operations.RegisterDriverEndpointInternalServerError{}, response)
+
+	mObjects.serviceBroker.AssertCalled(t, "Delete", ccapi.BrokerName("usb"))
+	var saved []config.BrokerAccount
+	for _, call := range provider.Calls {
+		if call.Method == "SetBrokerAccounts" {
+			saved = call.Arguments.Get(0).([]config.BrokerAccount)
+		}
+	}
+	assert.Equal(accounts, saved)
 }
 
 func Test_UpdateInstanceEndpoint(t *testing.T) {
@@ -134,6 +265,8 @@ func Test_UpdateInstanceEndpoint(t *testing.T) {
 
 	var instanceInfo config.Instance
 	instanceInfo.Name = "testInstance"
+	instanceInfo.Service.Name = "testInstance"
+	instanceInfo.Service.Tags = []string{"testInstance"}
 
 	var testConfig config.Config
 	testConfig.Instances = make(map[string]config.Instance)
@@ -143,19 +276,171 @@ func Test_UpdateInstanceEndpoint(t *testing.T) {
 	provider.On("LoadConfiguration").Return(&testConfig, nil)
 
 	provider.On("GetInstance", mock.Anything).Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
 
 	params := &operations.UpdateDriverEndpointParams{}
+	params.DriverEndpointID = "testInstanceID"
 	params.DriverEndpoint = &genmodel.DriverEndpoint{}
 	params.DriverEndpoint.ID = "testInstanceID"
-	name := "testInstance"
+	name := "renamedInstance"
 	params.DriverEndpoint.Name = &name
-	params.DriverEndpoint.EndpointURL = "http://127.0.0.1:8080"
+	params.DriverEndpoint.EndpointURL = "https://127.0.0.1:8443"
 	params.DriverEndpoint.AuthenticationKey = "authkey"
+	caCert := "cacert"
+	params.DriverEndpoint.CaCertificate = &caCert
+	skipSSL := true
+	params.DriverEndpoint.SkipSSLValidation = &skipSSL
 
 	provider.On("InstanceNameExists", mock.Anything).Return(false, nil)
-	provider.On("SetInstance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	provider.On("SetInstance", mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("CheckServiceNameExists", mock.Anything).Return(false, nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", ccapi.ServiceName("renamedInstance")).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
+	mObjects.csmClient.Mock.On("GetStatus").Return("", nil)
+
+	var validated csm.Endpoint
+	newCSMClient = func(_ lager.Logger, endpoint csm.Endpoint) (csm.CSM, error) {
+		validated = endpoint
+		return mObjects.csmClient, nil
+	}
+	defer func() { newCSMClient = csm.NewCSMClient }()
+
 	response := mObjects.usbMgmt.UpdateDriverEndpointHandler.Handle(*params, true)
 	assert.IsType(&operations.UpdateDriverEndpointOK{}, response)
+
+	driverEndpoint := response.(*operations.UpdateDriverEndpointOK).Payload
+	assert.Equal("renamedInstance", *driverEndpoint.Name)
+	assert.Equal("cacert", *driverEndpoint.CaCertificate)
+	assert.True(*driverEndpoint.SkipSSLValidation)
+
+	assert.Equal(csm.Endpoint{
+		Name:              "renamedInstance",
+		TargetURL:         "https://127.0.0.1:8443",
+		AuthenticationKey: "authkey",
+		CaCert:            "cacert",
+		SkipSSLValidation: true,
+	}, validated)
+	mObjects.csmClients.AssertNotCalled(t, "GetClient", mock.Anything, mock.Anything)
+	mObjects.csmClients.AssertCalled(t, "Remove", "testInstanceID")
+	provider.AssertCalled(t, "SetInstance", "testInstanceID", mock.MatchedBy(func(instance config.Instance) bool {
+		return instance.Service.Name == "renamedInstance" && instance.Service.Tags[0] == "renamedInstance"
+	}))
+}
+
+func Test_UpdateInstanceEndpointRejectedByCSM(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	instanceInfo.TargetURL = "https://127.0.0.1:8443"
+	instanceInfo.CaCert = "cacert"
+
+	provider.On("GetInstance", mock.Anything).Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
+	mObjects.csmClient.Mock.On("GetStatus").Return("", fmt.Errorf("x509: certificate signed by unknown authority"))
+
+	var validated csm.Endpoint
+	newCSMClient = func(_ lager.Logger, endpoint csm.Endpoint) (csm.CSM, error) {
+		validated = endpoint
+		return mObjects.csmClient, nil
+	}
+	defer func() { newCSMClient = csm.NewCSMClient }()
+
+	params := &operations.UpdateDriverEndpointParams{}
+	params.DriverEndpointID = "testInstanceID"
+	params.DriverEndpoint = &genmodel.DriverEndpoint{}
+	caCert := ""
+	params.DriverEndpoint.CaCertificate = &caCert
+
+	response := mObjects.usbMgmt.UpdateDriverEndpointHandler.Handle(*params, true)
+	assert.IsType(&operations.UpdateDriverEndpointBadRequest{}, response)
+	assert.Equal("x509: certificate signed by unknown authority", response.(*operations.UpdateDriverEndpointBadRequest).Payload)
+
+	assert.Equal("", validated.CaCert)
+	assert.Equal("https://127.0.0.1:8443", validated.TargetURL)
+	provider.AssertNotCalled(t, "SetInstance", mock.Anything, mock.Anything)
+	mObjects.csmClients.AssertNotCalled(t, "GetClient", mock.Anything, mock.Anything)
+	mObjects.csmClients.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func Test_UpdateDriverEndpointService(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	instanceInfo.Service = brokermodel.CatalogService{
+		ID:          "serviceID",
+		Name:        "testInstance",
+		Description: "Default service",
+		Tags:        []string{"testInstance"},
+		Bindable:    true,
+		Metadata:    brokermodel.MetaData{"display_name": "servicename"},
+	}
+
+	var testConfig config.Config
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("GetInstance", "testInstanceID").Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
+	provider.On("SetService", "testInstanceID", mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", ccapi.ServiceName("testInstance")).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", ccapi.ServiceGUID("serviceguid")).Return(nil)
+
+	planUpdateable := true
+	params := &operations.UpdateDriverEndpointServiceParams{}
+	params.DriverEndpointID = "testInstanceID"
+	params.Service = &genmodel.DriverEndpointService{
+		Name:           "renamed",
+		Description:    "A database",
+		Tags:           []string{"sql", "database"},
+		PlanUpdateable: &planUpdateable,
+		DisplayName:    "Database",
+		ImageURL:       "https://example.com/database.png",
+	}
+
+	response := mObjects.usbMgmt.UpdateDriverEndpointServiceHandler.Handle(*params, true)
+	assert.IsType(&operations.UpdateDriverEndpointServiceOK{}, response)
+
+	service := response.(*operations.UpdateDriverEndpointServiceOK).Payload
+	assert.Equal("testInstance", service.Name)
+	assert.Equal("A database", service.Description)
+	assert.Equal([]string{"sql", "database"}, service.Tags)
+	assert.True(*service.Bindable)
+	assert.True(*service.PlanUpdateable)
+	assert.Equal("Database", service.DisplayName)
+	assert.Equal("https://example.com/database.png", service.ImageURL)
+
+	provider.AssertCalled(t, "SetService", "testInstanceID", brokermodel.CatalogService{
+		ID:             "serviceID",
+		Name:           "testInstance",
+		Description:    "A database",
+		Tags:           []string{"sql", "database"},
+		Bindable:       true,
+		PlanUpdateable: true,
+		Metadata: brokermodel.MetaData{
+			"display_name": "servicename",
+			"displayName":  "Database",
+			"imageUrl":     "https://example.com/database.png",
+		},
+	})
+	mObjects.serviceBroker.AssertCalled(t, "EnableServiceAccess", ccapi.ServiceGUID("serviceguid"))
 }
 
 func Test_GetDriverEndpoint(t *testing.T) {
@@ -180,6 +465,221 @@ func Test_GetDriverEndpoint(t *testing.T) {
 
 }
 
+func Test_PingDriverEndpoint(t *testing.T) {
+
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	provider.On("GetInstance", "testInstanceID").Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("GetInstance", "missingInstanceID").Return(nil, "", nil)
+	mObjects.csmClient.Mock.On("GetStatusResponse").Return(nil, &csm.Error{StatusCode: 401, Message: "invalid token"})
+
+	params := &operations.PingDriverEndpointParams{}
+	params.DriverEndpointID = "testInstanceID"
+
+	response := mObjects.usbMgmt.PingDriverEndpointHandler.Handle(*params, true)
+	assert.IsType(&operations.PingDriverEndpointOK{}, response)
+
+	ping := response.(*operations.PingDriverEndpointOK).Payload
+	assert.True(*ping.Reachable)
+	assert.Equal("authentication", ping.ErrorType)
+	assert.Equal("invalid token", ping.Error)
+
+	params.DriverEndpointID = "missingInstanceID"
+	response = mObjects.usbMgmt.PingDriverEndpointHandler.Handle(*params, true)
+	assert.IsType(&operations.PingDriverEndpointNotFound{}, response)
+}
+
+func Test_CreateDial(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	instanceInfo.Dials = map[string]config.Dial{
+		"defaultDialID": {Plan: brokermodel.Plan{ID: "defaultPlanID", Name: "default"}},
+	}
+
+	var testConfig config.Config
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("GetInstance", "testInstanceID").Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
+	provider.On("SetDial", "testInstanceID", mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
+
+	params := &operations.CreateDialParams{}
+	params.DriverEndpointID = "testInstanceID"
+	name := "large"
+	free := false
+	params.Dial = &genmodel.Dial{
+		Name:          &name,
+		Description:   "large plan",
+		Free:          &free,
+		Bullets:       []string{"10 GB of storage"},
+		Costs:         []*genmodel.DialCost{{Amount: map[string]float64{"usd": 10}, Unit: "MONTHLY"}},
+		Configuration: map[string]interface{}{"size": "large"},
+	}
+
+	response := mObjects.usbMgmt.CreateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.CreateDialCreated{}, response)
+
+	dial := response.(*operations.CreateDialCreated).Payload
+	assert.NotEmpty(dial.ID)
+	assert.NotEmpty(dial.PlanID)
+	assert.Equal("large", *dial.Name)
+	assert.False(*dial.Free)
+	assert.Equal("large", dial.DisplayName)
+	assert.Equal([]string{"10 GB of storage"}, dial.Bullets)
+	assert.Equal(map[string]interface{}{"size": "large"}, dial.Configuration)
+	mObjects.serviceBroker.AssertCalled(t, "EnableServiceAccess", mock.Anything)
+
+	name = "default"
+	response = mObjects.usbMgmt.CreateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.CreateDialConflict{}, response)
+
+	name = "extra large"
+	response = mObjects.usbMgmt.CreateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.CreateDialBadRequest{}, response)
+	provider.AssertNumberOfCalls(t, "SetDial", 1)
+}
+
+func Test_UpdateDial(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	defaultDial := config.Dial{Plan: brokermodel.Plan{ID: "defaultPlanID", Name: "default"}}
+	largeDial := config.Dial{Plan: brokermodel.Plan{ID: "largePlanID", Name: "large"}}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	instanceInfo.Dials = map[string]config.Dial{
+		"defaultDialID": defaultDial,
+		"largeDialID":   largeDial,
+	}
+
+	var testConfig config.Config
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("GetInstance", "testInstanceID").Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
+	provider.On("GetDial", "largeDialID").Return(&largeDial, "testInstanceID", nil)
+	provider.On("SetDial", "testInstanceID", "largeDialID", mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
+
+	params := &operations.UpdateDialParams{}
+	params.DriverEndpointID = "testInstanceID"
+	params.DialID = "largeDialID"
+	name := "extra large"
+	params.Dial = &genmodel.Dial{Name: &name}
+
+	response := mObjects.usbMgmt.UpdateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.UpdateDialBadRequest{}, response)
+	provider.AssertNotCalled(t, "SetDial", "testInstanceID", "largeDialID", mock.Anything)
+
+	name = "default"
+	response = mObjects.usbMgmt.UpdateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.UpdateDialConflict{}, response)
+
+	name = "xlarge"
+	response = mObjects.usbMgmt.UpdateDialHandler.Handle(*params, true)
+	assert.IsType(&operations.UpdateDialOK{}, response)
+
+	dial := response.(*operations.UpdateDialOK).Payload
+	assert.Equal("largePlanID", dial.PlanID)
+	assert.Equal("xlarge", *dial.Name)
+}
+
+func Test_DeleteDial(t *testing.T) {
+	assert := assert.New(t)
+	provider := new(mocks.Provider)
+
+	mObjects, err := initMgmt(provider)
+	if err != nil {
+		t.Error(err)
+	}
+
+	defaultDial := config.Dial{Plan: brokermodel.Plan{ID: "defaultPlanID", Name: "default"}}
+	largeDial := config.Dial{Plan: brokermodel.Plan{ID: "largePlanID", Name: "large"}}
+	ccDial := config.Dial{Plan: brokermodel.Plan{ID: "ccPlanID", Name: "cc"}}
+
+	var instanceInfo config.Instance
+	instanceInfo.Name = "testInstance"
+	instanceInfo.Dials = map[string]config.Dial{
+		"defaultDialID": defaultDial,
+		"largeDialID":   largeDial,
+		"ccDialID":      ccDial,
+	}
+
+	var testConfig config.Config
+	testConfig.ManagementAPI = &config.ManagementAPI{}
+	testConfig.ManagementAPI.BrokerName = "usb"
+	provider.On("LoadConfiguration").Return(&testConfig, nil)
+	provider.On("GetInstance", "testInstanceID").Return(&instanceInfo, "testInstanceID", nil)
+	provider.On("LoadDriverInstance", "testInstanceID").Return(&instanceInfo, nil)
+	provider.On("GetDial", "defaultDialID").Return(&defaultDial, "testInstanceID", nil)
+	provider.On("GetDial", "largeDialID").Return(&largeDial, "testInstanceID", nil)
+	provider.On("GetDial", "otherDialID").Return(&largeDial, "otherInstanceID", nil)
+	provider.On("GetDial", "ccDialID").Return(&ccDial, "testInstanceID", nil)
+	provider.On("GetServiceInstances").Return(map[string]config.ServiceInstance{
+		"serviceInstanceID": {PlanID: "defaultPlanID"},
+	}, nil)
+	provider.On("DeleteDial", "largeDialID").Return(nil)
+	mObjects.serviceBroker.Mock.On("CheckPlanServiceInstancesExist", "largePlanID").Return(false, nil)
+	mObjects.serviceBroker.Mock.On("CheckPlanServiceInstancesExist", "ccPlanID").Return(true, nil)
+	mObjects.serviceBroker.Mock.On("GetServiceBrokerGUIDByName", mock.Anything).Return(ccapi.BrokerGUID("aguid"), nil)
+	mObjects.serviceBroker.Mock.On("Update", ccapi.BrokerGUID("aguid"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mObjects.serviceBroker.Mock.On("GetServiceGUIDByName", mock.Anything).Return(ccapi.ServiceGUID("serviceguid"), nil)
+	mObjects.serviceBroker.Mock.On("EnableServiceAccess", mock.Anything).Return(nil)
+
+	params := &operations.DeleteDialParams{}
+	params.DriverEndpointID = "testInstanceID"
+
+	params.DialID = "defaultDialID"
+	response := mObjects.usbMgmt.DeleteDialHandler.Handle(*params, true)
+	assert.IsType(&operations.DeleteDialConflict{}, response)
+	provider.AssertNotCalled(t, "DeleteDial", "defaultDialID")
+
+	params.DialID = "otherDialID"
+	response = mObjects.usbMgmt.DeleteDialHandler.Handle(*params, true)
+	assert.IsType(&operations.DeleteDialNotFound{}, response)
+
+	params.DialID = "ccDialID"
+	response = mObjects.usbMgmt.DeleteDialHandler.Handle(*params, true)
+	assert.IsType(&operations.DeleteDialConflict{}, response)
+	provider.AssertNotCalled(t, "DeleteDial", "ccDialID")
+
+	params.DialID = "largeDialID"
+	response = mObjects.usbMgmt.DeleteDialHandler.Handle(*params, true)
+	assert.IsType(&operations.DeleteDialNoContent{}, response)
+	provider.AssertCalled(t, "DeleteDial", "largeDialID")
+}
+
 func Test_GetDriverEndpoints(t *testing.T) {
 	assert := assert.New(t)
 	provider := new(mocks.Provider)
@@ -227,7 +727,7 @@ func Test_UnregisterDriverEndpoint(t *testing.T) {
 	provider.On("GetInstance", mock.Anything).Return(&instanceInfo, "testInstanceID", nil)
 	provider.On("DeleteInstance", mock.Anything).Return(nil)
 
-	mObjects.serviceBroker.Mock.On("Delete", testConfig.ManagementAPI.BrokerName).Return(nil)
+	mObjects.serviceBroker.Mock.On("Delete", ccapi.BrokerName(testConfig.ManagementAPI.BrokerName)).Return(nil)
 	mObjects.serviceBroker.Mock.On("CheckServiceInstancesExist", mock.Anything).Return(false)
 
 	params := &operations.UnregisterDriverInstanceParams{}
@@ -235,4 +735,24 @@ func Test_UnregisterDriverEndpoint(t *testing.T) {
 
 	response := mObjects.usbMgmt.UnregisterDriverInstanceHandler.Handle(*params, true)
 	assert.IsType(&operations.UnregisterDriverInstanceNoContent{}, response)
+	mObjects.csmClients.AssertCalled(t, "Remove", "testInstanceID")
+}
+
+func Test_PathTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	templates, err := metrics.SpecPathTemplates(SwaggerJSON)
+	assert.NoError(err)
+
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	assert.NoError(json.Unmarshal(SwaggerJSON, &spec))
+	assert.NotEmpty(spec.Paths)
+
+	parameter := regexp.MustCompile(`\{[^}]+\}`)
+	for route := range spec.Paths {
+		assert.Equal(route, templates.Template(parameter.ReplaceAllString(route, "c0ffee")))
+	}
+	assert.Equal("other", templates.Template("/driver_endpoints/c0ffee/unknown"))
 }
@@ -0,0 +1,32 @@
+package mgmt
+
+import (
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+//catalogDashboardClient converts the dashboard client of a driver endpoint to the one published in the broker catalog
+func catalogDashboardClient(client *genmodel.DashboardClient) *brokermodel.DashboardClient {
+	if client == nil {
+		return nil
+	}
+
+	return &brokermodel.DashboardClient{
+		ID:          *client.ID,
+		Secret:      *client.Secret,
+		RedirectURI: *client.RedirectURI,
+	}
+}
+
+//endpointDashboardClient converts the dashboard client published in the broker catalog to the one of a driver endpoint
+func endpointDashboardClient(client *brokermodel.DashboardClient) *genmodel.DashboardClient {
+	if client == nil || client.ID == "" {
+		return nil
+	}
+
+	return &genmodel.DashboardClient{
+		ID:          &client.ID,
+		Secret:      &client.Secret,
+		RedirectURI: &client.RedirectURI,
+	}
+}
@@ -0,0 +1,45 @@
+package mgmt
+
+import (
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CatalogDashboardClient(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(catalogDashboardClient(nil))
+
+	client := catalogDashboardClient(&genmodel.DashboardClient{
+		ID:          swag.String("client"),
+		Secret:      swag.String("secret"),
+		RedirectURI: swag.String("https://dashboard.example.com/callback"),
+	})
+	assert.Equal(&brokermodel.DashboardClient{
+		ID:          "client",
+		Secret:      "secret",
+		RedirectURI: "https://dashboard.example.com/callback",
+	}, client)
+}
+
+func Test_EndpointDashboardClient(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(endpointDashboardClient(nil))
+	assert.Nil(endpointDashboardClient(&brokermodel.DashboardClient{}))
+
+	catalogClient := &brokermodel.DashboardClient{
+		ID:          "client",
+		Secret:      "secret",
+		RedirectURI: "https://dashboard.example.com/callback",
+	}
+	client := endpointDashboardClient(catalogClient)
+	assert.Equal("client", swag.StringValue(client.ID))
+	assert.Equal("secret", swag.StringValue(client.Secret))
+	assert.Equal("https://dashboard.example.com/callback", swag.StringValue(client.RedirectURI))
+	assert.Equal(catalogClient, catalogDashboardClient(client))
+}
@@ -0,0 +1,203 @@
+package mgmt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/SUSE/cf-usb/lib/brokermodel"
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/SUSE/cf-usb/lib/mgmt/cc_integration/ccapi"
+	"github.com/pivotal-golang/lager"
+)
+
+//dialPlanMetadata holds the fields of the plan metadata displayed by graphical clients
+type dialPlanMetadata struct {
+	DisplayName string               `json:"displayName,omitempty"`
+	Bullets     []string             `json:"bullets,omitempty"`
+	Costs       []*genmodel.DialCost `json:"costs,omitempty"`
+}
+
+//newDial builds the dial described by model, offering the plan planID
+func newDial(model *genmodel.Dial, planID string) (config.Dial, error) {
+	var dial config.Dial
+
+	free := true
+	if model.Free != nil {
+		free = *model.Free
+	}
+
+	displayName := model.DisplayName
+	if displayName == "" {
+		displayName = *model.Name
+	}
+
+	dial.Plan = brokermodel.Plan{
+		ID:          planID,
+		Name:        *model.Name,
+		Description: model.Description,
+		Free:        free,
+		Metadata: &brokermodel.PlanMetadata{
+			Name:        displayName,
+			Description: model.Description,
+			Metadata: dialPlanMetadata{
+				DisplayName: displayName,
+				Bullets:     model.Bullets,
+				Costs:       model.Costs,
+			},
+		},
+	}
+
+	if model.Schemas != nil {
+		var schemas brokermodel.Schemas
+		err := convert(model.Schemas, &schemas)
+		if err != nil {
+			return dial, fmt.Errorf("invalid schemas: %s", err)
+		}
+		dial.Plan.Schemas = &schemas
+	}
+
+	configuration := json.RawMessage([]byte("{}"))
+	if model.Configuration != nil {
+		data, err := json.Marshal(model.Configuration)
+		if err != nil {
+			return dial, fmt.Errorf("invalid configuration: %s", err)
+		}
+		configuration = json.RawMessage(data)
+	}
+	dial.Configuration = &configuration
+
+	return dial, nil
+}
+
+//dialModel describes the dial dialID in the management API
+func dialModel(dialID string, dial config.Dial) (*genmodel.Dial, error) {
+	name := dial.Plan.Name
+	free := dial.Plan.Free
+
+	model := &genmodel.Dial{
+		ID:          dialID,
+		PlanID:      dial.Plan.ID,
+		Name:        &name,
+		Description: dial.Plan.Description,
+		Free:        &free,
+	}
+
+	if dial.Plan.Metadata != nil {
+		var metadata dialPlanMetadata
+		if dial.Plan.Metadata.Metadata != nil {
+			err := convert(dial.Plan.Metadata.Metadata, &metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+		model.DisplayName = metadata.DisplayName
+		if model.DisplayName == "" {
+			model.DisplayName = dial.Plan.Metadata.Name
+		}
+		model.Bullets = metadata.Bullets
+		model.Costs = metadata.Costs
+	}
+
+	if dial.Plan.Schemas != nil {
+		err := convert(dial.Plan.Schemas, &model.Schemas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dial.Configuration != nil && len(*dial.Configuration) > 0 {
+		err := json.Unmarshal(*dial.Configuration, &model.Configuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return model, nil
+}
+
+//convert copies value into result through their JSON representation
+func convert(value interface{}, result interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+//dialPlanNameExists checks if another dial than dialID of the driver endpoint offers a plan called name
+func dialPlanNameExists(dials map[string]config.Dial, name string, dialID string) bool {
+	for id, dial := range dials {
+		if id != dialID && dial.Plan.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+//planHasServiceInstances checks if service instances still use planID, either in the registry of the broker or in the
+//cloud controller, which also knows the instances provisioned before the registry existed
+func planHasServiceInstances(configProvider config.Provider, ccServiceBroker ccapi.USBServiceBroker, planID string) (bool, error) {
+	instances, err := configProvider.GetServiceInstances()
+	if err != nil {
+		return false, err
+	}
+
+	for _, instance := range instances {
+		if instance.PlanID == planID {
+			return true, nil
+		}
+	}
+
+	return ccServiceBroker.CheckPlanServiceInstancesExist(planID)
+}
+
+//refreshCatalog has the cloud controller fetch the catalog of the broker again, so that the changes made to the plans
+//of serviceName are applied, and enables the access to the plans of serviceName
+func refreshCatalog(log lager.Logger, configProvider config.Provider, ccServiceBroker ccapi.USBServiceBroker, serviceName string) error {
+	config, err := configProvider.LoadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	brokerName := defaultBrokerName
+	if len(config.ManagementAPI.BrokerName) > 0 {
+		brokerName = ccapi.BrokerName(config.ManagementAPI.BrokerName)
+	}
+
+	brokerGUID, err := ccServiceBroker.GetServiceBrokerGUIDByName(brokerName)
+	if err != nil {
+		log.Error("get-service-broker-failed", err)
+		return err
+	}
+	if brokerGUID == "" {
+		return fmt.Errorf("Broker %s guid not found", brokerName)
+	}
+
+	err = ccServiceBroker.Update(brokerGUID, brokerName, config.BrokerAPI.ExternalURL, "", "")
+	if err != nil {
+		log.Error("update-service-broker-failed", err)
+		return err
+	}
+
+	serviceGUID, err := ccServiceBroker.GetServiceGUIDByName(ccapi.ServiceName(serviceName))
+	if err != nil {
+		log.Error("get-service-guid-by-name-failed", err)
+		return err
+	}
+
+	err = ccServiceBroker.EnableServiceAccess(serviceGUID)
+	if err != nil {
+		log.Error("enable-service-access-failed", err)
+		return err
+	}
+
+	return nil
+}
+
+//dialsByName sorts the dials of a driver endpoint by the name of their plan
+type dialsByName []*genmodel.Dial
+
+func (dials dialsByName) Len() int           { return len(dials) }
+func (dials dialsByName) Swap(i, j int)      { dials[i], dials[j] = dials[j], dials[i] }
+func (dials dialsByName) Less(i, j int) bool { return *dials[i].Name < *dials[j].Name }
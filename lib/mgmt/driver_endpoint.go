@@ -0,0 +1,67 @@
+package mgmt
+
+import (
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+//driverEndpointModel describes the driver endpoint instanceID in the management API
+func driverEndpointModel(instanceID string, instance config.Instance) *genmodel.DriverEndpoint {
+	name := instance.Name
+	skipSSL := instance.SkipSsl
+
+	var caCert *string
+	if instance.CaCert != "" {
+		caCert = &instance.CaCert
+	}
+
+	return &genmodel.DriverEndpoint{
+		ID:                instanceID,
+		Name:              &name,
+		EndpointURL:       instance.TargetURL,
+		AuthenticationKey: instance.AuthenticationKey,
+		CaCertificate:     caCert,
+		SkipSSLValidation: &skipSSL,
+		Metadata:          map[string]string(instance.Service.Metadata),
+		DashboardClient:   endpointDashboardClient(instance.Service.DashboardClient),
+	}
+}
+
+//updateDriverEndpoint returns instance with the fields set in update applied, renaming the driver endpoint renames
+//the service it offers in the catalog as well
+func updateDriverEndpoint(instance config.Instance, update *genmodel.DriverEndpoint) config.Instance {
+	if update.Name != nil && *update.Name != instance.Name {
+		tags := make([]string, len(instance.Service.Tags))
+		for i, tag := range instance.Service.Tags {
+			if tag == instance.Name {
+				tag = *update.Name
+			}
+			tags[i] = tag
+		}
+		if instance.Service.Tags != nil {
+			instance.Service.Tags = tags
+		}
+		instance.Service.Name = *update.Name
+		instance.Name = *update.Name
+	}
+	if update.EndpointURL != "" {
+		instance.TargetURL = update.EndpointURL
+	}
+	if update.AuthenticationKey != "" {
+		instance.AuthenticationKey = update.AuthenticationKey
+	}
+	if update.CaCertificate != nil {
+		instance.CaCert = *update.CaCertificate
+	}
+	if update.SkipSSLValidation != nil {
+		instance.SkipSsl = *update.SkipSSLValidation
+	}
+	if update.Metadata != nil {
+		instance.Service.Metadata = map[string]string(update.Metadata)
+	}
+	if update.DashboardClient != nil {
+		instance.Service.DashboardClient = catalogDashboardClient(update.DashboardClient)
+	}
+
+	return instance
+}
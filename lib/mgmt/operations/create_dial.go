@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// CreateDialHandlerFunc turns a function with the right signature into a create dial handler
+type CreateDialHandlerFunc func(CreateDialParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn CreateDialHandlerFunc) Handle(params CreateDialParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// CreateDialHandler interface for that can handle valid create dial params
+type CreateDialHandler interface {
+	Handle(CreateDialParams, interface{}) middleware.Responder
+}
+
+// NewCreateDial creates a new http.Handler for the create dial operation
+func NewCreateDial(ctx *middleware.Context, handler CreateDialHandler) *CreateDial {
+	return &CreateDial{Context: ctx, Handler: handler}
+}
+
+/*CreateDial swagger:route POST /driver_endpoints/{driver_endpoint_id}/dials createDial
+
+Creates a dial for a driver endpoint and updates the catalog of the
+Cloud Foundry service broker with its plan.
+
+
+*/
+type CreateDial struct {
+	Context *middleware.Context
+	Handler CreateDialHandler
+}
+
+func (o *CreateDial) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewCreateDialParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
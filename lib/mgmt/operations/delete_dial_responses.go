@@ -0,0 +1,118 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+)
+
+/*DeleteDialNoContent Dial deleted
+
+swagger:response deleteDialNoContent
+*/
+type DeleteDialNoContent struct {
+}
+
+// NewDeleteDialNoContent creates DeleteDialNoContent with default headers values
+func NewDeleteDialNoContent() *DeleteDialNoContent {
+	return &DeleteDialNoContent{}
+}
+
+// WriteResponse to the client
+func (o *DeleteDialNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(204)
+}
+
+/*DeleteDialNotFound Not Found
+
+swagger:response deleteDialNotFound
+*/
+type DeleteDialNotFound struct {
+}
+
+// NewDeleteDialNotFound creates DeleteDialNotFound with default headers values
+func NewDeleteDialNotFound() *DeleteDialNotFound {
+	return &DeleteDialNotFound{}
+}
+
+// WriteResponse to the client
+func (o *DeleteDialNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+}
+
+/*DeleteDialConflict Conflict
+
+swagger:response deleteDialConflict
+*/
+type DeleteDialConflict struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewDeleteDialConflict creates DeleteDialConflict with default headers values
+func NewDeleteDialConflict() *DeleteDialConflict {
+	return &DeleteDialConflict{}
+}
+
+// WithPayload adds the payload to the delete dial conflict response
+func (o *DeleteDialConflict) WithPayload(payload string) *DeleteDialConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete dial conflict response
+func (o *DeleteDialConflict) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteDialConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
+/*DeleteDialInternalServerError Unexpected error
+
+swagger:response deleteDialInternalServerError
+*/
+type DeleteDialInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewDeleteDialInternalServerError creates DeleteDialInternalServerError with default headers values
+func NewDeleteDialInternalServerError() *DeleteDialInternalServerError {
+	return &DeleteDialInternalServerError{}
+}
+
+// WithPayload adds the payload to the delete dial internal server error response
+func (o *DeleteDialInternalServerError) WithPayload(payload string) *DeleteDialInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete dial internal server error response
+func (o *DeleteDialInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteDialInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
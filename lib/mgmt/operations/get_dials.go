@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// GetDialsHandlerFunc turns a function with the right signature into a get dials handler
+type GetDialsHandlerFunc func(GetDialsParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetDialsHandlerFunc) Handle(params GetDialsParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// GetDialsHandler interface for that can handle valid get dials params
+type GetDialsHandler interface {
+	Handle(GetDialsParams, interface{}) middleware.Responder
+}
+
+// NewGetDials creates a new http.Handler for the get dials operation
+func NewGetDials(ctx *middleware.Context, handler GetDialsHandler) *GetDials {
+	return &GetDials{Context: ctx, Handler: handler}
+}
+
+/*GetDials swagger:route GET /driver_endpoints/{driver_endpoint_id}/dials getDials
+
+Lists the dials of a driver endpoint. Each dial is a plan of the service
+offered by the driver endpoint and the configuration of that plan.
+
+
+*/
+type GetDials struct {
+	Context *middleware.Context
+	Handler GetDialsHandler
+}
+
+func (o *GetDials) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewGetDialsParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,102 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+/*GetDialsOK OK
+
+swagger:response getDialsOK
+*/
+type GetDialsOK struct {
+
+	// In: body
+	Payload []*genmodel.Dial `json:"body,omitempty"`
+}
+
+// NewGetDialsOK creates GetDialsOK with default headers values
+func NewGetDialsOK() *GetDialsOK {
+	return &GetDialsOK{}
+}
+
+// WithPayload adds the payload to the get dials o k response
+func (o *GetDialsOK) WithPayload(payload []*genmodel.Dial) *GetDialsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get dials o k response
+func (o *GetDialsOK) SetPayload(payload []*genmodel.Dial) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDialsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
+/*GetDialsNotFound Not Found
+
+swagger:response getDialsNotFound
+*/
+type GetDialsNotFound struct {
+}
+
+// NewGetDialsNotFound creates GetDialsNotFound with default headers values
+func NewGetDialsNotFound() *GetDialsNotFound {
+	return &GetDialsNotFound{}
+}
+
+// WriteResponse to the client
+func (o *GetDialsNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+}
+
+/*GetDialsInternalServerError Unexpected error
+
+swagger:response getDialsInternalServerError
+*/
+type GetDialsInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewGetDialsInternalServerError creates GetDialsInternalServerError with default headers values
+func NewGetDialsInternalServerError() *GetDialsInternalServerError {
+	return &GetDialsInternalServerError{}
+}
+
+// WithPayload adds the payload to the get dials internal server error response
+func (o *GetDialsInternalServerError) WithPayload(payload string) *GetDialsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get dials internal server error response
+func (o *GetDialsInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDialsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
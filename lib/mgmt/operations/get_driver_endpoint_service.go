@@ -0,0 +1,64 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// GetDriverEndpointServiceHandlerFunc turns a function with the right signature into a get driver endpoint service handler
+type GetDriverEndpointServiceHandlerFunc func(GetDriverEndpointServiceParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetDriverEndpointServiceHandlerFunc) Handle(params GetDriverEndpointServiceParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// GetDriverEndpointServiceHandler interface for that can handle valid get driver endpoint service params
+type GetDriverEndpointServiceHandler interface {
+	Handle(GetDriverEndpointServiceParams, interface{}) middleware.Responder
+}
+
+// NewGetDriverEndpointService creates a new http.Handler for the get driver endpoint service operation
+func NewGetDriverEndpointService(ctx *middleware.Context, handler GetDriverEndpointServiceHandler) *GetDriverEndpointService {
+	return &GetDriverEndpointService{Context: ctx, Handler: handler}
+}
+
+/*GetDriverEndpointService swagger:route GET /driver_endpoints/{driver_endpoint_id}/service getDriverEndpointService
+
+Gets the service offered by a driver endpoint in the catalog of the broker
+
+
+*/
+type GetDriverEndpointService struct {
+	Context *middleware.Context
+	Handler GetDriverEndpointServiceHandler
+}
+
+func (o *GetDriverEndpointService) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewGetDriverEndpointServiceParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
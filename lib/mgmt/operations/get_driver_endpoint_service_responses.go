@@ -0,0 +1,103 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+/*GetDriverEndpointServiceOK OK
+
+swagger:response getDriverEndpointServiceOK
+*/
+type GetDriverEndpointServiceOK struct {
+
+	// In: body
+	Payload *genmodel.DriverEndpointService `json:"body,omitempty"`
+}
+
+// NewGetDriverEndpointServiceOK creates GetDriverEndpointServiceOK with default headers values
+func NewGetDriverEndpointServiceOK() *GetDriverEndpointServiceOK {
+	return &GetDriverEndpointServiceOK{}
+}
+
+// WithPayload adds the payload to the get driver endpoint service o k response
+func (o *GetDriverEndpointServiceOK) WithPayload(payload *genmodel.DriverEndpointService) *GetDriverEndpointServiceOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get driver endpoint service o k response
+func (o *GetDriverEndpointServiceOK) SetPayload(payload *genmodel.DriverEndpointService) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDriverEndpointServiceOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*GetDriverEndpointServiceNotFound Not Found
+
+swagger:response getDriverEndpointServiceNotFound
+*/
+type GetDriverEndpointServiceNotFound struct {
+}
+
+// NewGetDriverEndpointServiceNotFound creates GetDriverEndpointServiceNotFound with default headers values
+func NewGetDriverEndpointServiceNotFound() *GetDriverEndpointServiceNotFound {
+	return &GetDriverEndpointServiceNotFound{}
+}
+
+// WriteResponse to the client
+func (o *GetDriverEndpointServiceNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+}
+
+/*GetDriverEndpointServiceInternalServerError Unexpected error
+
+swagger:response getDriverEndpointServiceInternalServerError
+*/
+type GetDriverEndpointServiceInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewGetDriverEndpointServiceInternalServerError creates GetDriverEndpointServiceInternalServerError with default headers values
+func NewGetDriverEndpointServiceInternalServerError() *GetDriverEndpointServiceInternalServerError {
+	return &GetDriverEndpointServiceInternalServerError{}
+}
+
+// WithPayload adds the payload to the get driver endpoint service internal server error response
+func (o *GetDriverEndpointServiceInternalServerError) WithPayload(payload string) *GetDriverEndpointServiceInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get driver endpoint service internal server error response
+func (o *GetDriverEndpointServiceInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDriverEndpointServiceInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
@@ -29,7 +29,9 @@ func NewPingDriverEndpoint(ctx *middleware.Context, handler PingDriverEndpointHa
 
 /*PingDriverEndpoint swagger:route GET /driver_endpoint/{driver_endpoint_id}/ping pingDriverEndpoint
 
-Pings a driver endpoint to determine basic health status
+Pings a driver endpoint to determine basic health status. The CSM of the driver endpoint is
+asked for its status with the authentication key of the driver endpoint, the result tells an
+unreachable CSM from a rejected key or a failing service.
 
 
 */
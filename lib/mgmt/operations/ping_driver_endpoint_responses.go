@@ -7,6 +7,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
 )
 
 /*PingDriverEndpointOK OK
@@ -14,6 +16,9 @@ import (
 swagger:response pingDriverEndpointOK
 */
 type PingDriverEndpointOK struct {
+
+	// In: body
+	Payload *genmodel.DriverEndpointPing `json:"body,omitempty"`
 }
 
 // NewPingDriverEndpointOK creates PingDriverEndpointOK with default headers values
@@ -21,10 +26,26 @@ func NewPingDriverEndpointOK() *PingDriverEndpointOK {
 	return &PingDriverEndpointOK{}
 }
 
+// WithPayload adds the payload to the ping driver endpoint o k response
+func (o *PingDriverEndpointOK) WithPayload(payload *genmodel.DriverEndpointPing) *PingDriverEndpointOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the ping driver endpoint o k response
+func (o *PingDriverEndpointOK) SetPayload(payload *genmodel.DriverEndpointPing) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *PingDriverEndpointOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
 /*PingDriverEndpointNotFound Not Found
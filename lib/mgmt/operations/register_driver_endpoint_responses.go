@@ -84,14 +84,14 @@ func (o *RegisterDriverEndpointConflict) WriteResponse(rw http.ResponseWriter, p
 
 }
 
-/*RegisterDriverEndpointInternalServerError Unexpected error
+/*RegisterDriverEndpointInternalServerError Unexpected error, the changes made by the registration have been undone
 
 swagger:response registerDriverEndpointInternalServerError
 */
 type RegisterDriverEndpointInternalServerError struct {
 
 	// In: body
-	Payload string `json:"body,omitempty"`
+	Payload *genmodel.RegistrationError `json:"body,omitempty"`
 }
 
 // NewRegisterDriverEndpointInternalServerError creates RegisterDriverEndpointInternalServerError with default headers values
@@ -100,13 +100,13 @@ func NewRegisterDriverEndpointInternalServerError() *RegisterDriverEndpointInter
 }
 
 // WithPayload adds the payload to the register driver endpoint internal server error response
-func (o *RegisterDriverEndpointInternalServerError) WithPayload(payload string) *RegisterDriverEndpointInternalServerError {
+func (o *RegisterDriverEndpointInternalServerError) WithPayload(payload *genmodel.RegistrationError) *RegisterDriverEndpointInternalServerError {
 	o.Payload = payload
 	return o
 }
 
 // SetPayload sets the payload to the register driver endpoint internal server error response
-func (o *RegisterDriverEndpointInternalServerError) SetPayload(payload string) {
+func (o *RegisterDriverEndpointInternalServerError) SetPayload(payload *genmodel.RegistrationError) {
 	o.Payload = payload
 }
 
@@ -114,8 +114,9 @@ func (o *RegisterDriverEndpointInternalServerError) SetPayload(payload string) {
 func (o *RegisterDriverEndpointInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
 	rw.WriteHeader(500)
-	if err := producer.Produce(rw, o.Payload); err != nil {
-		panic(err) // let the recovery middleware deal with this
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
 	}
-
 }
@@ -0,0 +1,64 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// RotateBrokerPasswordHandlerFunc turns a function with the right signature into a rotate broker password handler
+type RotateBrokerPasswordHandlerFunc func(interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn RotateBrokerPasswordHandlerFunc) Handle(principal interface{}) middleware.Responder {
+	return fn(principal)
+}
+
+// RotateBrokerPasswordHandler interface for that can handle valid rotate broker password params
+type RotateBrokerPasswordHandler interface {
+	Handle(interface{}) middleware.Responder
+}
+
+// NewRotateBrokerPassword creates a new http.Handler for the rotate broker password operation
+func NewRotateBrokerPassword(ctx *middleware.Context, handler RotateBrokerPasswordHandler) *RotateBrokerPassword {
+	return &RotateBrokerPassword{Context: ctx, Handler: handler}
+}
+
+/*RotateBrokerPassword swagger:route POST /rotate_broker_password rotateBrokerPassword
+
+Gives the account the Cloud Controller uses to connect to the broker a new password
+and updates the broker registration with it. The previous password is accepted
+until the Cloud Controller uses the new one.
+
+
+*/
+type RotateBrokerPassword struct {
+	Context *middleware.Context
+	Handler RotateBrokerPasswordHandler
+}
+
+func (o *RotateBrokerPassword) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, nil); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
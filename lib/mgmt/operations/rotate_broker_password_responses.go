@@ -0,0 +1,64 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+)
+
+/*RotateBrokerPasswordOK Successful response
+
+swagger:response rotateBrokerPasswordOK
+*/
+type RotateBrokerPasswordOK struct {
+}
+
+// NewRotateBrokerPasswordOK creates RotateBrokerPasswordOK with default headers values
+func NewRotateBrokerPasswordOK() *RotateBrokerPasswordOK {
+	return &RotateBrokerPasswordOK{}
+}
+
+// WriteResponse to the client
+func (o *RotateBrokerPasswordOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+}
+
+/*RotateBrokerPasswordInternalServerError Unexpected error
+
+swagger:response rotateBrokerPasswordInternalServerError
+*/
+type RotateBrokerPasswordInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewRotateBrokerPasswordInternalServerError creates RotateBrokerPasswordInternalServerError with default headers values
+func NewRotateBrokerPasswordInternalServerError() *RotateBrokerPasswordInternalServerError {
+	return &RotateBrokerPasswordInternalServerError{}
+}
+
+// WithPayload adds the payload to the rotate broker password internal server error response
+func (o *RotateBrokerPasswordInternalServerError) WithPayload(payload string) *RotateBrokerPasswordInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the rotate broker password internal server error response
+func (o *RotateBrokerPasswordInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RotateBrokerPasswordInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
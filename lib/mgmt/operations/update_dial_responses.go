@@ -0,0 +1,175 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+/*UpdateDialOK Sucessful response
+
+swagger:response updateDialOK
+*/
+type UpdateDialOK struct {
+
+	// In: body
+	Payload *genmodel.Dial `json:"body,omitempty"`
+}
+
+// NewUpdateDialOK creates UpdateDialOK with default headers values
+func NewUpdateDialOK() *UpdateDialOK {
+	return &UpdateDialOK{}
+}
+
+// WithPayload adds the payload to the update dial o k response
+func (o *UpdateDialOK) WithPayload(payload *genmodel.Dial) *UpdateDialOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update dial o k response
+func (o *UpdateDialOK) SetPayload(payload *genmodel.Dial) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDialOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*UpdateDialBadRequest Invalid dial
+
+swagger:response updateDialBadRequest
+*/
+type UpdateDialBadRequest struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUpdateDialBadRequest creates UpdateDialBadRequest with default headers values
+func NewUpdateDialBadRequest() *UpdateDialBadRequest {
+	return &UpdateDialBadRequest{}
+}
+
+// WithPayload adds the payload to the update dial bad request response
+func (o *UpdateDialBadRequest) WithPayload(payload string) *UpdateDialBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update dial bad request response
+func (o *UpdateDialBadRequest) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDialBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
+/*UpdateDialNotFound Not Found
+
+swagger:response updateDialNotFound
+*/
+type UpdateDialNotFound struct {
+}
+
+// NewUpdateDialNotFound creates UpdateDialNotFound with default headers values
+func NewUpdateDialNotFound() *UpdateDialNotFound {
+	return &UpdateDialNotFound{}
+}
+
+// WriteResponse to the client
+func (o *UpdateDialNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+}
+
+/*UpdateDialConflict Conflict
+
+swagger:response updateDialConflict
+*/
+type UpdateDialConflict struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUpdateDialConflict creates UpdateDialConflict with default headers values
+func NewUpdateDialConflict() *UpdateDialConflict {
+	return &UpdateDialConflict{}
+}
+
+// WithPayload adds the payload to the update dial conflict response
+func (o *UpdateDialConflict) WithPayload(payload string) *UpdateDialConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update dial conflict response
+func (o *UpdateDialConflict) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDialConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
+/*UpdateDialInternalServerError Unexpected error
+
+swagger:response updateDialInternalServerError
+*/
+type UpdateDialInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUpdateDialInternalServerError creates UpdateDialInternalServerError with default headers values
+func NewUpdateDialInternalServerError() *UpdateDialInternalServerError {
+	return &UpdateDialInternalServerError{}
+}
+
+// WithPayload adds the payload to the update dial internal server error response
+func (o *UpdateDialInternalServerError) WithPayload(payload string) *UpdateDialInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update dial internal server error response
+func (o *UpdateDialInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDialInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
@@ -29,7 +29,7 @@ func NewUpdateDriverEndpoint(ctx *middleware.Context, handler UpdateDriverEndpoi
 
 /*UpdateDriverEndpoint swagger:route PUT /driver_endpoints/{driver_endpoint_id} updateDriverEndpoint
 
-Update a driver endpoint
+Update a driver endpoint, the connectivity to its CSM is checked before the changes are saved. Renaming a driver endpoint renames the service it offers in the catalog of the broker. Omitted fields keep their current value, an empty caCertificate removes the CA certificate.
 
 
 */
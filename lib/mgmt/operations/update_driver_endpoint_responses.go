@@ -48,6 +48,42 @@ func (o *UpdateDriverEndpointOK) WriteResponse(rw http.ResponseWriter, producer
 	}
 }
 
+/*UpdateDriverEndpointBadRequest The CSM of the driver endpoint rejected the updated settings
+
+swagger:response updateDriverEndpointBadRequest
+*/
+type UpdateDriverEndpointBadRequest struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUpdateDriverEndpointBadRequest creates UpdateDriverEndpointBadRequest with default headers values
+func NewUpdateDriverEndpointBadRequest() *UpdateDriverEndpointBadRequest {
+	return &UpdateDriverEndpointBadRequest{}
+}
+
+// WithPayload adds the payload to the update driver endpoint bad request response
+func (o *UpdateDriverEndpointBadRequest) WithPayload(payload string) *UpdateDriverEndpointBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update driver endpoint bad request response
+func (o *UpdateDriverEndpointBadRequest) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDriverEndpointBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
+
 /*UpdateDriverEndpointNotFound Not Found
 
 swagger:response updateDriverEndpointNotFound
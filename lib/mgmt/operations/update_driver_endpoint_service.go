@@ -0,0 +1,65 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	middleware "github.com/go-openapi/runtime/middleware"
+)
+
+// UpdateDriverEndpointServiceHandlerFunc turns a function with the right signature into a update driver endpoint service handler
+type UpdateDriverEndpointServiceHandlerFunc func(UpdateDriverEndpointServiceParams, interface{}) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn UpdateDriverEndpointServiceHandlerFunc) Handle(params UpdateDriverEndpointServiceParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
+}
+
+// UpdateDriverEndpointServiceHandler interface for that can handle valid update driver endpoint service params
+type UpdateDriverEndpointServiceHandler interface {
+	Handle(UpdateDriverEndpointServiceParams, interface{}) middleware.Responder
+}
+
+// NewUpdateDriverEndpointService creates a new http.Handler for the update driver endpoint service operation
+func NewUpdateDriverEndpointService(ctx *middleware.Context, handler UpdateDriverEndpointServiceHandler) *UpdateDriverEndpointService {
+	return &UpdateDriverEndpointService{Context: ctx, Handler: handler}
+}
+
+/*UpdateDriverEndpointService swagger:route PUT /driver_endpoints/{driver_endpoint_id}/service updateDriverEndpointService
+
+Updates the service offered by a driver endpoint and the catalog of the
+Cloud Foundry service broker. Fields that are not set are left unchanged.
+
+
+*/
+type UpdateDriverEndpointService struct {
+	Context *middleware.Context
+	Handler UpdateDriverEndpointServiceHandler
+}
+
+func (o *UpdateDriverEndpointService) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, _ := o.Context.RouteInfo(r)
+	var Params = NewUpdateDriverEndpointServiceParams()
+
+	uprinc, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc
+	}
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params, principal) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,97 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+// NewUpdateDriverEndpointServiceParams creates a new UpdateDriverEndpointServiceParams object
+// with the default values initialized.
+func NewUpdateDriverEndpointServiceParams() UpdateDriverEndpointServiceParams {
+	var ()
+	return UpdateDriverEndpointServiceParams{}
+}
+
+// UpdateDriverEndpointServiceParams contains all the bound params for the update driver endpoint service operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters updateDriverEndpointService
+type UpdateDriverEndpointServiceParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request
+
+	/*Driver Endpoint ID
+	  Required: true
+	  In: path
+	*/
+	DriverEndpointID string
+	/*Updated information for the service of the driver endpoint
+	  Required: true
+	  In: body
+	*/
+	Service *genmodel.DriverEndpointService
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls
+func (o *UpdateDriverEndpointServiceParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+	o.HTTPRequest = r
+
+	rDriverEndpointID, rhkDriverEndpointID, _ := route.Params.GetOK("driver_endpoint_id")
+	if err := o.bindDriverEndpointID(rDriverEndpointID, rhkDriverEndpointID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if runtime.HasBody(r) {
+		defer r.Body.Close()
+		var body genmodel.DriverEndpointService
+		if err := route.Consumer.Consume(r.Body, &body); err != nil {
+			if err == io.EOF {
+				res = append(res, errors.Required("service", "body"))
+			} else {
+				res = append(res, errors.NewParseError("service", "body", "", err))
+			}
+
+		} else {
+			if err := body.Validate(route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			if len(res) == 0 {
+				o.Service = &body
+			}
+		}
+
+	} else {
+		res = append(res, errors.Required("service", "body"))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (o *UpdateDriverEndpointServiceParams) bindDriverEndpointID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	o.DriverEndpointID = raw
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/SUSE/cf-usb/lib/genmodel"
+)
+
+/*UpdateDriverEndpointServiceOK Sucessful response
+
+swagger:response updateDriverEndpointServiceOK
+*/
+type UpdateDriverEndpointServiceOK struct {
+
+	// In: body
+	Payload *genmodel.DriverEndpointService `json:"body,omitempty"`
+}
+
+// NewUpdateDriverEndpointServiceOK creates UpdateDriverEndpointServiceOK with default headers values
+func NewUpdateDriverEndpointServiceOK() *UpdateDriverEndpointServiceOK {
+	return &UpdateDriverEndpointServiceOK{}
+}
+
+// WithPayload adds the payload to the update driver endpoint service o k response
+func (o *UpdateDriverEndpointServiceOK) WithPayload(payload *genmodel.DriverEndpointService) *UpdateDriverEndpointServiceOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update driver endpoint service o k response
+func (o *UpdateDriverEndpointServiceOK) SetPayload(payload *genmodel.DriverEndpointService) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDriverEndpointServiceOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		if err := producer.Produce(rw, o.Payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*UpdateDriverEndpointServiceNotFound Not Found
+
+swagger:response updateDriverEndpointServiceNotFound
+*/
+type UpdateDriverEndpointServiceNotFound struct {
+}
+
+// NewUpdateDriverEndpointServiceNotFound creates UpdateDriverEndpointServiceNotFound with default headers values
+func NewUpdateDriverEndpointServiceNotFound() *UpdateDriverEndpointServiceNotFound {
+	return &UpdateDriverEndpointServiceNotFound{}
+}
+
+// WriteResponse to the client
+func (o *UpdateDriverEndpointServiceNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+}
+
+/*UpdateDriverEndpointServiceInternalServerError Unexpected error
+
+swagger:response updateDriverEndpointServiceInternalServerError
+*/
+type UpdateDriverEndpointServiceInternalServerError struct {
+
+	// In: body
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUpdateDriverEndpointServiceInternalServerError creates UpdateDriverEndpointServiceInternalServerError with default headers values
+func NewUpdateDriverEndpointServiceInternalServerError() *UpdateDriverEndpointServiceInternalServerError {
+	return &UpdateDriverEndpointServiceInternalServerError{}
+}
+
+// WithPayload adds the payload to the update driver endpoint service internal server error response
+func (o *UpdateDriverEndpointServiceInternalServerError) WithPayload(payload string) *UpdateDriverEndpointServiceInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update driver endpoint service internal server error response
+func (o *UpdateDriverEndpointServiceInternalServerError) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateDriverEndpointServiceInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if err := producer.Produce(rw, o.Payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
@@ -49,8 +49,18 @@ type UsbMgmtAPI struct {
 	// it performs authentication based on an api key Authorization provided in the header
 	AuthorizationAuth func(string) (interface{}, error)
 
+	// CreateDialHandler sets the operation handler for the create dial operation
+	CreateDialHandler CreateDialHandler
+	// DeleteDialHandler sets the operation handler for the delete dial operation
+	DeleteDialHandler DeleteDialHandler
+	// GetDialHandler sets the operation handler for the get dial operation
+	GetDialHandler GetDialHandler
+	// GetDialsHandler sets the operation handler for the get dials operation
+	GetDialsHandler GetDialsHandler
 	// GetDriverEndpointHandler sets the operation handler for the get driver endpoint operation
 	GetDriverEndpointHandler GetDriverEndpointHandler
+	// GetDriverEndpointServiceHandler sets the operation handler for the get driver endpoint service operation
+	GetDriverEndpointServiceHandler GetDriverEndpointServiceHandler
 	// GetDriverEndpointsHandler sets the operation handler for the get driver endpoints operation
 	GetDriverEndpointsHandler GetDriverEndpointsHandler
 	// GetInfoHandler sets the operation handler for the get info operation
@@ -59,12 +69,18 @@ type UsbMgmtAPI struct {
 	PingDriverEndpointHandler PingDriverEndpointHandler
 	// RegisterDriverEndpointHandler sets the operation handler for the register driver endpoint operation
 	RegisterDriverEndpointHandler RegisterDriverEndpointHandler
+	// RotateBrokerPasswordHandler sets the operation handler for the rotate broker password operation
+	RotateBrokerPasswordHandler RotateBrokerPasswordHandler
 	// UnregisterDriverInstanceHandler sets the operation handler for the unregister driver instance operation
 	UnregisterDriverInstanceHandler UnregisterDriverInstanceHandler
 	// UpdateCatalogHandler sets the operation handler for the update catalog operation
 	UpdateCatalogHandler UpdateCatalogHandler
+	// UpdateDialHandler sets the operation handler for the update dial operation
+	UpdateDialHandler UpdateDialHandler
 	// UpdateDriverEndpointHandler sets the operation handler for the update driver endpoint operation
 	UpdateDriverEndpointHandler UpdateDriverEndpointHandler
+	// UpdateDriverEndpointServiceHandler sets the operation handler for the update driver endpoint service operation
+	UpdateDriverEndpointServiceHandler UpdateDriverEndpointServiceHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -127,10 +143,30 @@ func (o *UsbMgmtAPI) Validate() error {
 		unregistered = append(unregistered, "AuthorizationAuth")
 	}
 
+	if o.CreateDialHandler == nil {
+		unregistered = append(unregistered, "CreateDialHandler")
+	}
+
+	if o.DeleteDialHandler == nil {
+		unregistered = append(unregistered, "DeleteDialHandler")
+	}
+
+	if o.GetDialHandler == nil {
+		unregistered = append(unregistered, "GetDialHandler")
+	}
+
+	if o.GetDialsHandler == nil {
+		unregistered = append(unregistered, "GetDialsHandler")
+	}
+
 	if o.GetDriverEndpointHandler == nil {
 		unregistered = append(unregistered, "GetDriverEndpointHandler")
 	}
 
+	if o.GetDriverEndpointServiceHandler == nil {
+		unregistered = append(unregistered, "GetDriverEndpointServiceHandler")
+	}
+
 	if o.GetDriverEndpointsHandler == nil {
 		unregistered = append(unregistered, "GetDriverEndpointsHandler")
 	}
@@ -147,6 +183,10 @@ func (o *UsbMgmtAPI) Validate() error {
 		unregistered = append(unregistered, "RegisterDriverEndpointHandler")
 	}
 
+	if o.RotateBrokerPasswordHandler == nil {
+		unregistered = append(unregistered, "RotateBrokerPasswordHandler")
+	}
+
 	if o.UnregisterDriverInstanceHandler == nil {
 		unregistered = append(unregistered, "UnregisterDriverInstanceHandler")
 	}
@@ -155,10 +195,18 @@ func (o *UsbMgmtAPI) Validate() error {
 		unregistered = append(unregistered, "UpdateCatalogHandler")
 	}
 
+	if o.UpdateDialHandler == nil {
+		unregistered = append(unregistered, "UpdateDialHandler")
+	}
+
 	if o.UpdateDriverEndpointHandler == nil {
 		unregistered = append(unregistered, "UpdateDriverEndpointHandler")
 	}
 
+	if o.UpdateDriverEndpointServiceHandler == nil {
+		unregistered = append(unregistered, "UpdateDriverEndpointServiceHandler")
+	}
+
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
 	}
@@ -242,11 +290,36 @@ func (o *UsbMgmtAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers[strings.ToUpper("POST")] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/driver_endpoints/{driver_endpoint_id}/dials"] = NewCreateDial(o.context, o.CreateDialHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers[strings.ToUpper("DELETE")] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/driver_endpoints/{driver_endpoint_id}/dials/{dial_id}"] = NewDeleteDial(o.context, o.DeleteDialHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/driver_endpoints/{driver_endpoint_id}/dials/{dial_id}"] = NewGetDial(o.context, o.GetDialHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/driver_endpoints/{driver_endpoint_id}/dials"] = NewGetDials(o.context, o.GetDialsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/driver_endpoints/{driver_endpoint_id}"] = NewGetDriverEndpoint(o.context, o.GetDriverEndpointHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/driver_endpoints/{driver_endpoint_id}/service"] = NewGetDriverEndpointService(o.context, o.GetDriverEndpointServiceHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers[strings.ToUpper("GET")] = make(map[string]http.Handler)
 	}
@@ -267,6 +340,11 @@ func (o *UsbMgmtAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/driver_endpoints"] = NewRegisterDriverEndpoint(o.context, o.RegisterDriverEndpointHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers[strings.ToUpper("POST")] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/rotate_broker_password"] = NewRotateBrokerPassword(o.context, o.RotateBrokerPasswordHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers[strings.ToUpper("DELETE")] = make(map[string]http.Handler)
 	}
@@ -277,11 +355,21 @@ func (o *UsbMgmtAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/update_catalog"] = NewUpdateCatalog(o.context, o.UpdateCatalogHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers[strings.ToUpper("PUT")] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/driver_endpoints/{driver_endpoint_id}/dials/{dial_id}"] = NewUpdateDial(o.context, o.UpdateDialHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers[strings.ToUpper("PUT")] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/driver_endpoints/{driver_endpoint_id}"] = NewUpdateDriverEndpoint(o.context, o.UpdateDriverEndpointHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers[strings.ToUpper("PUT")] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/driver_endpoints/{driver_endpoint_id}/service"] = NewUpdateDriverEndpointService(o.context, o.UpdateDriverEndpointServiceHandler)
+
 }
 
 // Serve creates a http handler to serve the API over HTTP
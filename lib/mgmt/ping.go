@@ -0,0 +1,78 @@
+package mgmt
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SUSE/cf-usb/lib/config"
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/go-openapi/swag"
+)
+
+//Kinds of failure reported by a driver endpoint ping
+const (
+	pingConnectionError     = "connection"
+	pingTLSError            = "tls"
+	pingAuthenticationError = "authentication"
+	pingCSMError            = "csm"
+)
+
+func instanceEndpoint(instance config.Instance) csm.Endpoint {
+	return csm.Endpoint{
+		Name:              instance.Name,
+		TargetURL:         instance.TargetURL,
+		AuthenticationKey: instance.AuthenticationKey,
+		CaCert:            instance.CaCert,
+		SkipSSLValidation: instance.SkipSsl,
+	}
+}
+
+//pingDriverEndpoint asks the CSM of a driver endpoint for its status and reports how it answered, a CSM that could
+//not be reached is told apart from one that rejected the authentication key or reported a failure
+func pingDriverEndpoint(client csm.CSM) *genmodel.DriverEndpointPing {
+	start := time.Now()
+	status, err := client.GetStatusResponse()
+
+	reachable := true
+	result := &genmodel.DriverEndpointPing{
+		Reachable: &reachable,
+		LatencyMs: int64(time.Since(start) / time.Millisecond),
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+
+		csmError, ok := err.(*csm.Error)
+		switch {
+		case ok && (csmError.StatusCode == http.StatusUnauthorized || csmError.StatusCode == http.StatusForbidden):
+			result.ErrorType = pingAuthenticationError
+		case ok:
+			result.ErrorType = pingCSMError
+		case csm.IsTLSError(err):
+			reachable = false
+			result.ErrorType = pingTLSError
+		default:
+			reachable = false
+			result.ErrorType = pingConnectionError
+		}
+		return result
+	}
+
+	result.Status = swag.StringValue(status.Status)
+	result.Message = swag.StringValue(status.Message)
+	result.ServiceType = status.ServiceType
+	for _, diagnostic := range status.Diagnostics {
+		if diagnostic == nil {
+			continue
+		}
+		result.Diagnostics = append(result.Diagnostics, &genmodel.DriverEndpointPingDiagnostic{
+			Name:        swag.StringValue(diagnostic.Name),
+			Description: swag.StringValue(diagnostic.Description),
+			Status:      swag.StringValue(diagnostic.Status),
+			Message:     swag.StringValue(diagnostic.Message),
+		})
+	}
+
+	return result
+}
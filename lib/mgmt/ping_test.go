@@ -0,0 +1,66 @@
+package mgmt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SUSE/cf-usb/lib/csm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingDriverEndpointErrorResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		code        int
+		contentType string
+		body        string
+		reachable   bool
+		errorType   string
+		message     string
+	}{
+		{http.StatusUnauthorized, "application/json", `{"message":"invalid token"}`, true, pingAuthenticationError, "invalid token"},
+		{http.StatusUnauthorized, "application/json", ``, true, pingAuthenticationError, "The CSM answered with 401 Unauthorized"},
+		{http.StatusForbidden, "application/json", ``, true, pingAuthenticationError, "The CSM answered with 403 Forbidden"},
+		{http.StatusUnauthorized, "application/json", `{}`, true, pingAuthenticationError, "The CSM answered with 401 Unauthorized"},
+		{http.StatusForbidden, "application/json", `{}`, true, pingAuthenticationError, "The CSM answered with 403 Forbidden"},
+		{http.StatusUnauthorized, "text/html", `<html>Unauthorized</html>`, true, pingAuthenticationError, "The CSM answered with 401 Unauthorized"},
+		{http.StatusForbidden, "text/plain", `forbidden`, true, pingAuthenticationError, "The CSM answered with 403 Forbidden"},
+		{http.StatusBadGateway, "", ``, true, pingCSMError, "The CSM answered with 502 Bad Gateway"},
+	}
+
+	for _, c := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c.contentType != "" {
+				w.Header().Set("Content-Type", c.contentType)
+			}
+			w.WriteHeader(c.code)
+			w.Write([]byte(c.body))
+		}))
+
+		client, err := csm.NewCSMClient(logger, csm.Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+		assert.NoError(err)
+
+		ping := pingDriverEndpoint(client)
+		assert.Equal(c.reachable, *ping.Reachable, "%d %q", c.code, c.body)
+		assert.Equal(c.errorType, ping.ErrorType, "%d %q", c.code, c.body)
+		assert.Equal(c.message, ping.Error, "%d %q", c.code, c.body)
+
+		server.Close()
+	}
+}
+
+func TestPingDriverEndpointUnreachable(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client, err := csm.NewCSMClient(logger, csm.Endpoint{TargetURL: server.URL, AuthenticationKey: "key"})
+	assert.NoError(err)
+
+	ping := pingDriverEndpoint(client)
+	assert.False(*ping.Reachable)
+	assert.Equal(pingConnectionError, ping.ErrorType)
+}
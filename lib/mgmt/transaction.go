@@ -0,0 +1,73 @@
+package mgmt
+
+import (
+	"github.com/SUSE/cf-usb/lib/genmodel"
+	"github.com/pivotal-golang/lager"
+)
+
+//States a step of a transaction is left in
+const (
+	stepSucceeded      = "succeeded"
+	stepFailed         = "failed"
+	stepRolledBack     = "rolled_back"
+	stepRollbackFailed = "rollback_failed"
+)
+
+type transactionStep struct {
+	undo   func() error
+	report *genmodel.RegistrationStep
+}
+
+//transaction runs the steps of an operation that changes both the configuration and the cloud controller, when a
+//step fails the steps that succeeded before it are undone in reverse order
+type transaction struct {
+	log   lager.Logger
+	steps []transactionStep
+}
+
+func newTransaction(log lager.Logger) *transaction {
+	return &transaction{log: log}
+}
+
+//run performs the step name. undo reverts it if a later step fails, it is nil for steps that change nothing
+func (t *transaction) run(name string, do func() error, undo func() error) error {
+	report := &genmodel.RegistrationStep{Name: name, Status: stepSucceeded}
+
+	err := do()
+	if err != nil {
+		t.log.Error("step-failed", err, lager.Data{"step": name})
+		report.Status = stepFailed
+		report.Error = err.Error()
+	} else {
+		t.log.Debug("step-succeeded", lager.Data{"step": name})
+	}
+
+	t.steps = append(t.steps, transactionStep{undo: undo, report: report})
+	return err
+}
+
+//rollback undoes the steps that succeeded, the most recent first, and reports the state every step was left in
+func (t *transaction) rollback(err error) *genmodel.RegistrationError {
+	for i := len(t.steps) - 1; i >= 0; i-- {
+		step := t.steps[i]
+		if step.report.Status != stepSucceeded || step.undo == nil {
+			continue
+		}
+
+		undoErr := step.undo()
+		if undoErr != nil {
+			t.log.Error("rollback-step-failed", undoErr, lager.Data{"step": step.report.Name})
+			step.report.Status = stepRollbackFailed
+			step.report.Error = undoErr.Error()
+			continue
+		}
+		t.log.Info("step-rolled-back", lager.Data{"step": step.report.Name})
+		step.report.Status = stepRolledBack
+	}
+
+	result := &genmodel.RegistrationError{Message: err.Error()}
+	for _, step := range t.steps {
+		result.Steps = append(result.Steps, step.report)
+	}
+	return result
+}